@@ -21,8 +21,13 @@ module "ecs" {
       port        = 8081
       cpu         = 256
       memory      = 512
-      environment = {}
-      expose_alb  = false
+      environment = {
+        SERVICE_TOKEN   = random_password.service_token.result
+        STORAGE_BACKEND = "file"
+      }
+      # Games in progress and the leaderboard survive redeploys
+      persistent_path = "/data"
+      expose_alb      = false
     }
     frontend-service = {
       image       = "${aws_ecr_repository.frontend_service.repository_url}:latest"
@@ -31,6 +36,8 @@ module "ecs" {
       memory      = 512
       environment = {
         GAME_SERVICE_URL = "http://game-service.${var.environment}-${var.project_name}.local:8081"
+        SESSION_SECRET   = random_password.session_secret.result
+        SERVICE_TOKEN    = random_password.service_token.result
       }
       expose_alb  = true
       host_header = "sihle.${data.cloudflare_zone.this.name}"
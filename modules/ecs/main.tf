@@ -276,6 +276,14 @@ resource "aws_ecs_task_definition" "services" {
         }
       ]
 
+      mountPoints = each.value.persistent_path == null ? [] : [
+        {
+          sourceVolume  = "data"
+          containerPath = each.value.persistent_path
+          readOnly      = false
+        }
+      ]
+
       environment = [
         for k, v in merge({
           PORT = tostring(each.value.port)
@@ -299,6 +307,21 @@ resource "aws_ecs_task_definition" "services" {
     }
   ])
 
+  dynamic "volume" {
+    for_each = each.value.persistent_path == null ? [] : [1]
+    content {
+      name = "data"
+      efs_volume_configuration {
+        file_system_id     = aws_efs_file_system.data[0].id
+        transit_encryption = "ENABLED"
+        authorization_config {
+          access_point_id = aws_efs_access_point.services[each.key].id
+          iam             = "ENABLED"
+        }
+      }
+    }
+  }
+
   tags = merge(var.tags, {
     Name        = "${var.environment}-${var.project_name}-${each.key}-task"
     Environment = var.environment
@@ -349,6 +372,10 @@ resource "aws_ecs_service" "services" {
   desired_count   = var.desired_count
   launch_type     = "FARGATE"
 
+  # Stop the old task before starting its replacement when they share files
+  deployment_minimum_healthy_percent = each.value.persistent_path == null ? 100 : 0
+  deployment_maximum_percent         = each.value.persistent_path == null ? 200 : 100
+
   network_configuration {
     security_groups = [aws_security_group.ecs_tasks.id]
     subnets         = var.private_subnet_ids
@@ -370,7 +397,7 @@ resource "aws_ecs_service" "services" {
     }
   }
 
-  depends_on = [aws_lb_listener.http]
+  depends_on = [aws_lb_listener.http, aws_efs_mount_target.data]
 
   tags = merge(var.tags, {
     Name        = "${var.environment}-${var.project_name}-${each.key}"
@@ -0,0 +1,90 @@
+# Persistent Storage - EFS for services that keep files across task restarts
+locals {
+  persistent_services = { for k, v in var.services : k => v if v.persistent_path != null }
+  storage_enabled     = length(local.persistent_services) > 0
+}
+
+resource "aws_efs_file_system" "data" {
+  count = local.storage_enabled ? 1 : 0
+
+  creation_token = "${var.environment}-${var.project_name}-data"
+  encrypted      = true
+
+  tags = merge(var.tags, {
+    Name        = "${var.environment}-${var.project_name}-data"
+    Environment = var.environment
+  })
+}
+
+resource "aws_security_group" "efs" {
+  count = local.storage_enabled ? 1 : 0
+
+  name_prefix = "${var.environment}-${var.project_name}-efs-"
+  vpc_id      = var.vpc_id
+
+  ingress {
+    description     = "NFS from ECS tasks"
+    from_port       = 2049
+    to_port         = 2049
+    protocol        = "tcp"
+    security_groups = [aws_security_group.ecs_tasks.id]
+  }
+
+  tags = merge(var.tags, {
+    Name        = "${var.environment}-${var.project_name}-efs-sg"
+    Environment = var.environment
+  })
+}
+
+resource "aws_efs_mount_target" "data" {
+  count = local.storage_enabled ? length(var.private_subnet_ids) : 0
+
+  file_system_id  = aws_efs_file_system.data[0].id
+  subnet_id       = var.private_subnet_ids[count.index]
+  security_groups = [aws_security_group.efs[0].id]
+}
+
+# Each service gets its own directory, owned by the distroless nonroot user
+# the images run as
+resource "aws_efs_access_point" "services" {
+  for_each = local.persistent_services
+
+  file_system_id = aws_efs_file_system.data[0].id
+
+  posix_user {
+    uid = 65532
+    gid = 65532
+  }
+
+  root_directory {
+    path = "/${each.key}"
+    creation_info {
+      owner_uid   = 65532
+      owner_gid   = 65532
+      permissions = "0750"
+    }
+  }
+
+  tags = merge(var.tags, {
+    Name        = "${var.environment}-${var.project_name}-${each.key}-data"
+    Environment = var.environment
+  })
+}
+
+resource "aws_iam_role_policy" "efs_access" {
+  count = local.storage_enabled ? 1 : 0
+
+  name = "${var.environment}-${var.project_name}-efs-access"
+  role = aws_iam_role.ecs_task_role.id
+
+  policy = jsonencode({
+    Version = "2012-10-17"
+    Statement = [
+      {
+        Effect   = "Allow"
+        Action   = ["elasticfilesystem:ClientMount", "elasticfilesystem:ClientWrite"]
+        Resource = aws_efs_file_system.data[0].arn
+      }
+    ]
+  })
+}
@@ -34,6 +34,9 @@ variable "services" {
     environment = optional(map(string), {})
     expose_alb  = optional(bool, false)
     host_header = optional(string, null)
+    # Container path mounted from EFS, for services that keep files. Run one
+    # task of such a service: the files are not safe to share between writers.
+    persistent_path = optional(string, null)
   }))
   default = {}
 }
@@ -0,0 +1,13 @@
+# Secrets shared by every task. Generated once and kept in state, so player
+# sessions stay valid across restarts and whichever task the ALB picks.
+resource "random_password" "session_secret" {
+  length  = 48
+  special = false
+}
+
+# Lets game-service tell the frontend, which checked the session, apart from
+# anything else that can reach it
+resource "random_password" "service_token" {
+  length  = 48
+  special = false
+}
@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"portfolio-frontend-service/internal/handlers"
+	"portfolio-frontend-service/internal/session"
 	"portfolio-frontend-service/pkg/config"
 	"portfolio-frontend-service/pkg/logger"
 
@@ -19,7 +20,14 @@ func main() {
 	cfg := config.Load()
 	log := logger.New(cfg.LogLevel)
 
-	handler := handlers.NewFrontendHandler(cfg.GameServiceURL, log)
+	secret := []byte(cfg.SessionSecret)
+	if len(secret) == 0 {
+		log.Warn("SESSION_SECRET not set, player sessions will not survive a restart")
+		secret = session.NewSecret()
+	}
+	sessions := session.NewSigner(secret, cfg.SecureCookies)
+
+	handler := handlers.NewFrontendHandler(cfg.GameServiceURL, cfg.ServiceToken, sessions, log)
 
 	r := mux.NewRouter()
 	
@@ -31,9 +39,21 @@ func main() {
 	
 	// Portfolio UI
 	r.HandleFunc("/", handler.Index).Methods("GET")
+	r.HandleFunc("/api/session", handler.GetSession).Methods("GET")
+	r.HandleFunc("/api/session", handler.CreateSession).Methods("POST")
 	r.HandleFunc("/api/game/start", handler.ProxyStartGame).Methods("POST")
+	r.HandleFunc("/api/game/active", handler.ProxyActiveGame).Methods("GET")
+	r.HandleFunc("/api/game/history", handler.ProxyHistory).Methods("GET")
 	r.HandleFunc("/api/game/move", handler.ProxyMove).Methods("POST")
+	r.HandleFunc("/api/game/moves", handler.ProxyMoves).Methods("POST")
+	r.HandleFunc("/api/game/join", handler.ProxyJoin).Methods("POST")
+	r.HandleFunc("/api/game/leave", handler.ProxyLeave).Methods("POST")
 	r.HandleFunc("/api/game/status", handler.ProxyStatus).Methods("GET")
+	r.HandleFunc("/api/game/ws", handler.ProxyGameSocket).Methods("GET")
+	r.HandleFunc("/api/game/spectate", handler.ProxySpectateSocket).Methods("GET")
+	r.HandleFunc("/api/game/featured", handler.ProxyFeatured).Methods("GET")
+	r.HandleFunc("/api/leaderboard", handler.ProxyLeaderboard).Methods("GET")
+	r.HandleFunc("/api/leaderboard/rank", handler.ProxyLeaderboardRank).Methods("GET")
 
 	srv := &http.Server{
 		Addr:         ":" + cfg.Port,
@@ -2,22 +2,48 @@ package handlers
 
 import (
 	"encoding/json"
-	"io"
 	"net/http"
 	"net/url"
+	"strings"
 
+	"portfolio-frontend-service/internal/session"
+
+	"github.com/gorilla/websocket"
 	"github.com/sirupsen/logrus"
 )
 
+var upgrader = websocket.Upgrader{
+	ReadBufferSize:  1024,
+	WriteBufferSize: 4096,
+}
+
+// Headers the game service reads the player identity from. They are always
+// set from the session cookie, never passed through from the browser.
+const (
+	playerIDHeader   = "X-Player-ID"
+	playerNameHeader = "X-Player-Name"
+	// serviceTokenHeader proves to the game service that the identity
+	// headers come from here
+	serviceTokenHeader = "X-Service-Token"
+)
+
 type FrontendHandler struct {
 	gameServiceURL string
+	serviceToken   string
+	sessions       *session.Signer
 	logger         *logrus.Logger
 	client         *http.Client
 }
 
-func NewFrontendHandler(gameServiceURL string, logger *logrus.Logger) *FrontendHandler {
+type SessionRequest struct {
+	Name string `json:"name"`
+}
+
+func NewFrontendHandler(gameServiceURL, serviceToken string, sessions *session.Signer, logger *logrus.Logger) *FrontendHandler {
 	return &FrontendHandler{
 		gameServiceURL: gameServiceURL,
+		serviceToken:   serviceToken,
+		sessions:       sessions,
 		logger:         logger,
 		client:         &http.Client{},
 	}
@@ -60,6 +86,13 @@ func (h *FrontendHandler) Index(w http.ResponseWriter, r *http.Request) {
         .experience h4 { color: #00ff88; margin: 20px 0 8px 0; font-size: 1.3em; }
         .experience ul { margin: 15px 0; padding-left: 25px; }
         .experience li { margin: 8px 0; line-height: 1.6; }
+        .play-area { display: flex; justify-content: center; align-items: flex-start; gap: 20px; flex-wrap: wrap; }
+        .leaderboard { min-width: 240px; background: rgba(0,255,136,0.08); padding: 15px 20px; border-radius: 10px; border: 1px solid rgba(0,255,136,0.2); text-align: left; }
+        .leaderboard h3 { margin: 0 0 10px 0; }
+        .leaderboard ol { margin: 0; padding-left: 25px; }
+        .leaderboard li { margin: 6px 0; display: flex; justify-content: space-between; gap: 15px; }
+        .leaderboard select, .controls input { padding: 8px; border-radius: 6px; border: 1px solid rgba(0,212,255,0.4); background: rgba(0,0,0,0.4); color: #fff; }
+        .notice { min-height: 1.4em; color: #ff6b6b; font-weight: bold; }
         .game-instructions { background: rgba(255,255,255,0.05); padding: 20px; border-radius: 10px; margin: 20px 0; border-left: 4px solid #00d4ff; }
     </style>
 </head>
@@ -138,18 +171,52 @@ func (h *FrontendHandler) Index(w http.ResponseWriter, r *http.Request) {
                 <div class="stat-label">Level</div>
                 <div class="stat-value" id="level">1</div>
             </div>
+            <div class="stat-box">
+                <div class="stat-label">Lives</div>
+                <div class="stat-value" id="lives">3</div>
+            </div>
+            <div class="stat-box">
+                <div class="stat-label">Power-ups</div>
+                <div class="stat-value" id="effects">-</div>
+            </div>
             <div class="stat-box">
                 <div class="stat-label">Status</div>
                 <div class="stat-value" id="gameStatus">Ready</div>
             </div>
         </div>
-        <canvas id="gameCanvas" width="800" height="600"></canvas>
+        <div class="play-area">
+            <canvas id="gameCanvas" width="800" height="600"></canvas>
+            <div class="leaderboard">
+                <h3>🏆 High Scores</h3>
+                <select id="leaderboardPeriod" onchange="loadLeaderboard()">
+                    <option value="all">All time</option>
+                    <option value="week">This week</option>
+                    <option value="day">Today</option>
+                </select>
+                <ol id="leaderboardEntries"></ol>
+                <p id="personalBest"></p>
+                <h3>🕹️ Your Games</h3>
+                <ol id="historyEntries"></ol>
+                <h3>👥 Crew</h3>
+                <ol id="crewEntries"></ol>
+                <p id="gameCode"></p>
+            </div>
+        </div>
         <div class="controls">
+            <input id="playerName" maxlength="20" placeholder="Nickname">
             <button onclick="startGame()">🎮 New Game</button>
             <button onmousedown="moveLeft()" onmouseup="stopMove()">← Move Left</button>
             <button onmousedown="moveRight()" onmouseup="stopMove()">Move Right →</button>
             <button onclick="shoot()">🚀 Fire Bullet</button>
         </div>
+        <div class="controls">
+            <input id="joinCode" maxlength="32" placeholder="Game code">
+            <button onclick="joinGame()">🤝 Join Co-op</button>
+            <button onclick="leaveGame()">🚪 Leave</button>
+            <button onclick="watchGame(document.getElementById('joinCode').value.trim())">👀 Watch</button>
+            <button onclick="watchFeatured()">⭐ Watch Featured</button>
+        </div>
+        <p id="notice" class="notice"></p>
         <div class="game-instructions">
             <p><strong>🎮 Controls:</strong> Arrow keys to move, Spacebar to shoot</p>
             <p><strong>🎯 Objective:</strong> Destroy all enemies to advance to the next level!</p>
@@ -159,35 +226,419 @@ func (h *FrontendHandler) Index(w http.ResponseWriter, r *http.Request) {
     
     <script>
         let currentGame = null;
+        // gameState is the last full state, kept current by applying deltas
+        let gameState = null;
         let canvas = document.getElementById('gameCanvas');
         let ctx = canvas.getContext('2d');
-        let gameLoop = null;
+        let socket = null;
+        let spectating = false;
         let moveInterval = null;
+        let playerId = null;
+        // you is this player's seat in the current game as the service last
+        // reported it; states carry no player ids to find it by
+        let you = null;
+        // Inputs are numbered so the service can drop retried ones; inputSeq
+        // is the last number handed out
+        let pendingInputs = [];
+        let inputSeq = 0;
+        let flushScheduled = false;
+        let flushing = false;
         
         document.addEventListener('keydown', handleKeyPress);
+        restoreSession();
+        
+        // Friendlier wording for the error codes a player can run into; other
+        // codes fall back to the title the service sent
+        const problemMessages = {
+            game_not_found: 'That game does not exist or has expired',
+            game_over: 'That game is already over',
+            game_full: 'That game is full',
+            not_seated: 'Join the game before playing',
+            input_backlog: 'Slow down, too many moves are queued',
+            spectator_move: 'Spectators cannot play',
+            invalid_nickname: 'Nicknames are up to 20 letters, digits, spaces, dashes or underscores',
+            session_required: 'Pick a nickname first',
+            service_unavailable: 'The game service is unavailable, try again shortly'
+        };
+        
+        // readProblem turns an error response into { code, message }
+        async function readProblem(response) {
+            let problem = {};
+            try {
+                problem = await response.json();
+            } catch (e) {}
+            const code = problem.code || 'http_' + response.status;
+            return { code: code, message: problemMessages[code] || problem.title || response.statusText, ackedSeq: problem.acked_seq };
+        }
+        
+        function showProblem(problem) {
+            const notice = document.getElementById('notice');
+            notice.textContent = problem.message + ' [' + problem.code + ']';
+            clearTimeout(showProblem.timer);
+            showProblem.timer = setTimeout(() => { notice.textContent = ''; }, 5000);
+        }
+        
+        // reportProblem shows a failed response unless its code is expected
+        async function reportProblem(response, ...expected) {
+            const problem = await readProblem(response);
+            if (!expected.includes(problem.code)) showProblem(problem);
+            return problem;
+        }
+        
+        // restoreSession fills in the nickname from the session cookie and
+        // resumes the player's game in progress after a reload
+        async function restoreSession() {
+            const response = await fetch('/api/session');
+            if (response.ok) {
+                const player = await response.json();
+                playerId = player.id;
+                document.getElementById('playerName').value = player.name;
+                
+                const active = await fetch('/api/game/active');
+                if (active.ok) {
+                    const game = await active.json();
+                    currentGame = game.id;
+                    gameState = game;
+                    you = game.you || null;
+                    syncInputSeq(game);
+                    renderGame(game);
+                    connectSocket(currentGame);
+                } else {
+                    await reportProblem(active, 'game_not_found');
+                }
+            } else {
+                await reportProblem(response, 'no_session');
+            }
+            loadLeaderboard();
+        }
+        
+        async function saveNickname() {
+            const name = document.getElementById('playerName').value.trim();
+            if (!name) return true;
+            
+            const session = await fetch('/api/session', {
+                method: 'POST',
+                headers: { 'Content-Type': 'application/json' },
+                body: JSON.stringify({ name: name })
+            });
+            if (!session.ok) {
+                await reportProblem(session);
+                return false;
+            }
+            playerId = (await session.json()).id;
+            return true;
+        }
         
         async function startGame() {
-            const response = await fetch('/api/game/start', { method: 'POST' });
+            if (!await saveNickname()) return;
+            const name = document.getElementById('playerName').value.trim();
+            
+            const response = await fetch('/api/game/start', {
+                method: 'POST',
+                headers: { 'Content-Type': 'application/json' },
+                body: JSON.stringify({ player: name })
+            });
+            if (!response.ok) {
+                await reportProblem(response);
+                return;
+            }
             const data = await response.json();
             currentGame = data.game_id;
+            spectating = false;
+            you = null;
             
-            if (gameLoop) clearInterval(gameLoop);
-            gameLoop = setInterval(updateGame, 100);
+            connectSocket(currentGame);
         }
         
-        async function makeMove(action, direction = '') {
-            if (!currentGame) return;
+        // watchGame follows a game read-only; keys and buttons do nothing
+        function watchGame(gameId) {
+            if (!gameId) return;
+            currentGame = gameId;
+            spectating = true;
+            you = null;
+            connectSocket(currentGame);
+        }
+        
+        async function watchFeatured() {
+            const response = await fetch('/api/game/featured');
+            if (!response.ok) {
+                await reportProblem(response);
+                return;
+            }
+            const game = await response.json();
+            gameState = game;
+            renderGame(game);
+            watchGame(game.id);
+        }
+        
+        // joinGame adds this player's ship to a friend's game
+        async function joinGame() {
+            const code = document.getElementById('joinCode').value.trim();
+            if (!code) return;
+            if (!document.getElementById('playerName').value.trim()) {
+                showProblem({ code: 'session_required', message: 'Pick a nickname before joining a game' });
+                return;
+            }
+            if (!await saveNickname()) return;
             
-            const response = await fetch('/api/game/move', {
+            const response = await fetch('/api/game/join', {
                 method: 'POST',
                 headers: { 'Content-Type': 'application/json' },
-                body: JSON.stringify({ game_id: currentGame, action: action, direction: direction })
+                body: JSON.stringify({ game_id: code })
             });
+            if (!response.ok) {
+                await reportProblem(response);
+                return;
+            }
+            const data = await response.json();
+            currentGame = data.game_id;
+            spectating = false;
+            you = null;
+            connectSocket(currentGame);
+        }
+        
+        async function leaveGame() {
+            if (!currentGame) return;
             
-            if (response.ok) {
-                const game = await response.json();
-                renderGame(game);
+            if (!spectating) {
+                const response = await fetch('/api/game/leave', {
+                    method: 'POST',
+                    headers: { 'Content-Type': 'application/json' },
+                    body: JSON.stringify({ game_id: currentGame })
+                });
+                // Leaving a finished or expired game is not worth a warning
+                if (!response.ok) await reportProblem(response, 'game_over', 'game_not_found');
+            }
+            currentGame = null;
+            if (socket) socket.close();
+        }
+        
+        function connectSocket(gameId) {
+            if (socket) socket.close();
+            
+            const scheme = location.protocol === 'https:' ? 'wss://' : 'ws://';
+            const path = spectating ? '/api/game/spectate' : '/api/game/ws';
+            socket = new WebSocket(scheme + location.host + path + '?game_id=' + encodeURIComponent(gameId));
+            socket.onmessage = (event) => {
+                const frame = JSON.parse(event.data);
+                if (frame.type === 'error' && frame.code === 'input_out_of_order') {
+                    resumeInputs(frame.acked_seq);
+                } else if (frame.type === 'error') {
+                    showProblem({ code: frame.code, message: problemMessages[frame.code] || frame.error });
+                } else if (!applyFrame(frame)) {
+                    // A delta we cannot apply means a frame went missing
+                    socket.send(JSON.stringify({ action: 'sync' }));
+                }
+            };
+            socket.onclose = () => {
+                if (socket && socket.readyState === WebSocket.CLOSED) socket = null;
+                // Finished games reach the leaderboard shortly after the socket closes
+                setTimeout(loadLeaderboard, 500);
+            };
+        }
+        
+        async function loadLeaderboard() {
+            const period = document.getElementById('leaderboardPeriod').value;
+            const response = await fetch('/api/leaderboard?limit=10&period=' + period);
+            if (!response.ok) {
+                await reportProblem(response);
+                return;
+            }
+            
+            const data = await response.json();
+            const list = document.getElementById('leaderboardEntries');
+            list.innerHTML = '';
+            data.entries.forEach(entry => {
+                const item = document.createElement('li');
+                const name = document.createElement('span');
+                name.textContent = entry.player;
+                const score = document.createElement('strong');
+                score.textContent = entry.score;
+                item.append(name, score);
+                list.appendChild(item);
+            });
+            if (data.entries.length === 0) {
+                list.innerHTML = '<li>No scores yet</li>';
+            }
+            
+            // Personal bests follow the session, not the nickname typed in
+            const best = document.getElementById('personalBest');
+            best.textContent = '';
+            if (playerId) {
+                const rank = await fetch('/api/leaderboard/rank');
+                if (rank.ok) {
+                    const data = await rank.json();
+                    best.textContent = 'Your best: ' + data.score + ' (#' + data.rank + ')';
+                } else {
+                    await reportProblem(rank, 'player_not_ranked', 'session_required');
+                }
+            }
+            loadHistory();
+        }
+        
+        async function loadHistory() {
+            const list = document.getElementById('historyEntries');
+            list.innerHTML = '';
+            const response = await fetch('/api/game/history?limit=5');
+            if (!response.ok) {
+                // Anonymous players have no history to show
+                await reportProblem(response, 'session_required');
+                return;
+            }
+            
+            const data = await response.json();
+            data.games.forEach(game => {
+                const item = document.createElement('li');
+                const when = document.createElement('span');
+                when.textContent = new Date(game.achieved_at).toLocaleString() + ' · L' + game.level;
+                const score = document.createElement('strong');
+                score.textContent = game.score;
+                item.append(when, score);
+                list.appendChild(item);
+            });
+        }
+        
+        // makeMove buffers an input; buffered inputs go out together once per frame
+        function makeMove(action, direction = '') {
+            if (!currentGame || spectating) return;
+            
+            pendingInputs.push({ seq: ++inputSeq, client_time: Date.now(), action: action, direction: direction || undefined });
+            scheduleFlush();
+        }
+        
+        function scheduleFlush() {
+            if (flushScheduled) return;
+            flushScheduled = true;
+            requestAnimationFrame(flushInputs);
+        }
+        
+        async function flushInputs() {
+            flushScheduled = false;
+            if (flushing || !currentGame || spectating || pendingInputs.length === 0) return;
+            const inputs = pendingInputs;
+            pendingInputs = [];
+            
+            // Moves go over the socket; state comes back on the next tick frame
+            if (socket && socket.readyState === WebSocket.OPEN) {
+                inputs.forEach(input => socket.send(JSON.stringify(input)));
+                return;
+            }
+            
+            // One request at a time keeps batches in sequence order
+            flushing = true;
+            let retry = false;
+            try {
+                // Ask for just the changes since the state we already hold
+                const since = gameState && gameState.id === currentGame ? '?since=' + gameState.tick : '';
+                const response = await fetch('/api/game/moves' + since, {
+                    method: 'POST',
+                    headers: { 'Content-Type': 'application/json' },
+                    body: JSON.stringify({ game_id: currentGame, inputs: inputs })
+                });
+                
+                if (response.ok) {
+                    applyFrame(await response.json());
+                } else if (response.status >= 502) {
+                    // The batch may or may not have landed; resending it with the
+                    // next input is safe because the service drops seqs it has seen
+                    retry = true;
+                    pendingInputs = inputs.concat(pendingInputs);
+                } else {
+                    // A rejected batch is not acknowledged, so what follows takes over its seqs
+                    const problem = await reportProblem(response, 'input_out_of_order');
+                    resumeInputs(problem.code === 'input_out_of_order' ? problem.ackedSeq : inputs[0].seq - 1);
+                }
+            } catch (e) {
+                retry = true;
+                pendingInputs = inputs.concat(pendingInputs);
+            } finally {
+                flushing = false;
+                if (!retry && pendingInputs.length > 0) scheduleFlush();
+            }
+        }
+        
+        // resumeInputs renumbers buffered inputs to follow on from acked, the
+        // last seq the service handled
+        function resumeInputs(acked) {
+            inputSeq = acked || 0;
+            pendingInputs.forEach(input => { input.seq = ++inputSeq; });
+        }
+        
+        // mySeat returns this player's seat in game, if they hold one
+        function mySeat(game) {
+            return you && (game.seats || []).find(seat => seat.slot === you.slot && !seat.left);
+        }
+        
+        // syncInputSeq catches up with a seat that handled inputs this page
+        // never sent, such as before a reload
+        function syncInputSeq(game) {
+            const seat = mySeat(game);
+            if (seat && (seat.acked_seq || 0) > inputSeq) resumeInputs(seat.acked_seq);
+        }
+        
+        // applyFrame takes a keyframe or applies a delta to gameState and
+        // redraws. It returns false for a delta that does not follow on from
+        // the state held, so the caller can ask for a keyframe.
+        function applyFrame(frame) {
+            if (frame.type === 'state') {
+                if (frame.game.id !== currentGame) return true;
+                gameState = frame.game;
+            } else if (frame.type === 'delta') {
+                if (!gameState || gameState.id !== currentGame || gameState.tick !== frame.delta.base_seq) return false;
+                applyDelta(gameState, frame.delta);
             }
+            if (frame.you) you = frame.you;
+            syncInputSeq(gameState);
+            renderGame(gameState);
+            return true;
+        }
+        
+        function applyDelta(game, delta) {
+            game.tick = delta.seq;
+            game.status = delta.status;
+            game.score = delta.score;
+            game.level = delta.level;
+            game.lives = delta.lives;
+            game.formation = delta.formation;
+            game.boss = delta.boss || null;
+            game.flags = delta.flags || [];
+            if (delta.seats) game.seats = delta.seats;
+            (delta.ships || []).forEach(ship => {
+                const previous = game.ships[ship.slot];
+                ship.bullets = previous ? previous.bullets : [];
+                game.ships[ship.slot] = ship;
+            });
+            
+            const removed = new Set(delta.removed || []);
+            const enemies = new Map(game.enemies.map(enemy => [enemy.id, enemy]));
+            (delta.enemies || []).forEach(enemy => enemies.set(enemy.id, enemy));
+            removed.forEach(id => {
+                const enemy = enemies.get(id);
+                if (enemy) enemy.active = false;
+            });
+            game.enemies = Array.from(enemies.values());
+            
+            // Bullets are keyed by id; removed ones are dropped outright
+            const bullets = new Map();
+            game.ships.forEach(ship => ship.bullets.forEach(bullet => bullets.set(bullet.id, {ship: ship.slot, bullet})));
+            (delta.bullets || []).forEach(bullet => bullets.set(bullet.id, {ship: bullet.ship, bullet}));
+            game.ships.forEach(ship => { ship.bullets = []; });
+            bullets.forEach(({ship, bullet}, id) => {
+                if (!removed.has(id)) game.ships[ship].bullets.push(bullet);
+            });
+            
+            const enemyBullets = new Map(game.enemy_bullets.map(bullet => [bullet.id, bullet]));
+            (delta.enemy_bullets || []).forEach(bullet => enemyBullets.set(bullet.id, bullet));
+            game.enemy_bullets = Array.from(enemyBullets.values()).filter(bullet => !removed.has(bullet.id));
+            
+            const powerUps = new Map((game.power_ups || []).map(item => [item.id, item]));
+            (delta.power_ups || []).forEach(item => powerUps.set(item.id, item));
+            game.power_ups = Array.from(powerUps.values()).filter(item => !removed.has(item.id));
+            
+            // Damaged bunkers arrive whole
+            const bunkers = new Map((game.bunkers || []).map(bunker => [bunker.id, bunker]));
+            (delta.bunkers || []).forEach(bunker => bunkers.set(bunker.id, bunker));
+            game.bunkers = Array.from(bunkers.values()).filter(bunker => !removed.has(bunker.id));
         }
         
         function moveLeft() {
@@ -226,25 +677,51 @@ func (h *FrontendHandler) Index(w http.ResponseWriter, r *http.Request) {
             }
         }
         
-        async function updateGame() {
-            if (!currentGame) return;
-            
-            // Trigger game update on server
-            await fetch('/api/game/move', {
-                method: 'POST',
-                headers: { 'Content-Type': 'application/json' },
-                body: JSON.stringify({ game_id: currentGame, action: 'update' })
+        const shipColors = ['#00ff00', '#00d4ff', '#ff66ff', '#ffaa00'];
+        const powerUpStyles = {
+            spread: {color: '#00ffff', letter: 'S', label: 'Spread'},
+            rapid_fire: {color: '#ffff00', letter: 'R', label: 'Rapid fire'},
+            shield: {color: '#6688ff', letter: 'O', label: 'Shield'},
+            extra_life: {color: '#00ff66', letter: '+', label: 'Extra life'}
+        };
+        
+        // renderEffects shows the power-ups active on the player's own ship,
+        // with the ticks each has left
+        function renderEffects(game) {
+            const seat = mySeat(game);
+            const ship = seat && (game.ships || [])[seat.slot];
+            const effects = (ship && ship.effects) || [];
+            document.getElementById('effects').textContent = effects.length === 0 ? '-' : effects
+                .map(effect => (powerUpStyles[effect.kind] || {label: effect.kind}).label + ' ' + Math.max(0, effect.expires_at - game.tick))
+                .join(', ');
+        }
+        
+        // renderCrew lists everyone seated in the game with their share of the score
+        function renderCrew(game) {
+            const list = document.getElementById('crewEntries');
+            list.innerHTML = '';
+            (game.seats || []).forEach(seat => {
+                if (seat.left) return;
+                const ship = (game.ships || [])[seat.slot];
+                const item = document.createElement('li');
+                const name = document.createElement('span');
+                name.textContent = seat.name;
+                name.style.color = shipColors[seat.slot % shipColors.length];
+                const score = document.createElement('strong');
+                score.textContent = ship ? ship.score : 0;
+                item.append(name, score);
+                list.appendChild(item);
             });
-            
-            // Get updated game state
-            const response = await fetch('/api/game/status?game_id=' + currentGame);
-            if (response.ok) {
-                const game = await response.json();
-                renderGame(game);
-            }
+            document.getElementById('gameCode').textContent = game.status === 'active' ? 'Game code: ' + game.id : '';
         }
         
         function renderGame(game) {
+            // Match the playfield size of the current level
+            if (game.world && (canvas.width !== game.world.width || canvas.height !== game.world.height)) {
+                canvas.width = game.world.width;
+                canvas.height = game.world.height;
+            }
+            
             // Clear canvas
             ctx.fillStyle = '#000';
             ctx.fillRect(0, 0, canvas.width, canvas.height);
@@ -252,17 +729,41 @@ func (h *FrontendHandler) Index(w http.ResponseWriter, r *http.Request) {
             // Update score and level
             document.getElementById('score').textContent = game.score;
             document.getElementById('level').textContent = game.level;
-            document.getElementById('gameStatus').textContent = game.status === 'active' ? 'Playing' : game.status.toUpperCase();
+            document.getElementById('lives').textContent = game.lives;
+            document.getElementById('gameStatus').textContent = game.status !== 'active' ? game.status.toUpperCase() : spectating ? 'Watching' : 'Playing';
+            
+            renderCrew(game);
+            renderEffects(game);
+            
+            // Draw bunkers cell by cell, dimmer as cells wear down
+            (game.bunkers || []).forEach(bunker => {
+                bunker.cells.forEach((row, y) => row.forEach((strength, x) => {
+                    if (strength <= 0) return;
+                    ctx.fillStyle = strength > 1 ? '#33cc33' : '#1a661a';
+                    ctx.fillRect(bunker.position.x + x * bunker.cell_size, bunker.position.y + y * bunker.cell_size, bunker.cell_size, bunker.cell_size);
+                }));
+            });
             
-            // Draw player (green rectangle)
-            if (game.player && game.player.active) {
-                ctx.fillStyle = '#00ff00';
-                ctx.fillRect(game.player.position.x, game.player.position.y, 30, 20);
+            // Draw ships, one colour per slot, flickering while invulnerable after a hit
+            (game.ships || []).forEach(ship => {
+                const flicker = ship.invulnerable_ticks > 0 && game.tick % 4 < 2;
+                if (!ship.active || flicker) return;
+                
+                ctx.fillStyle = shipColors[ship.slot % shipColors.length];
+                ctx.fillRect(ship.position.x, ship.position.y, 30, 20);
                 
                 // Player ship details
                 ctx.fillStyle = '#ffffff';
-                ctx.fillRect(game.player.position.x + 12, game.player.position.y - 5, 6, 8);
-            }
+                ctx.fillRect(ship.position.x + 12, ship.position.y - 5, 6, 8);
+                
+                if ((ship.effects || []).some(effect => effect.kind === 'shield')) {
+                    ctx.strokeStyle = powerUpStyles.shield.color;
+                    ctx.lineWidth = 2;
+                    ctx.beginPath();
+                    ctx.arc(ship.position.x + 15, ship.position.y + 8, 24, 0, 2 * Math.PI);
+                    ctx.stroke();
+                }
+            });
             
             // Draw enemies (red rectangles)
             ctx.fillStyle = '#ff0000';
@@ -278,16 +779,57 @@ func (h *FrontendHandler) Index(w http.ResponseWriter, r *http.Request) {
                 });
             }
             
+            // Draw the boss with its health bar across the top of the field
+            if (game.boss) {
+                const boss = game.boss;
+                ctx.fillStyle = '#aa00ff';
+                ctx.fillRect(boss.position.x, boss.position.y, 120, 60);
+                ctx.fillStyle = '#ffffff';
+                ctx.fillRect(boss.position.x + 25, boss.position.y + 20, 15, 10);
+                ctx.fillRect(boss.position.x + 80, boss.position.y + 20, 15, 10);
+                
+                const barWidth = canvas.width - 40;
+                ctx.fillStyle = '#333333';
+                ctx.fillRect(20, 20, barWidth, 10);
+                ctx.fillStyle = boss.phase >= 2 ? '#ff0000' : boss.phase === 1 ? '#ff8800' : '#aa00ff';
+                ctx.fillRect(20, 20, barWidth * boss.hp / boss.max_hp, 10);
+                ctx.fillStyle = '#ffffff';
+                ctx.font = '12px Arial';
+                ctx.fillText('BOSS ' + boss.hp + ' / ' + boss.max_hp, 20, 45);
+            }
+            
             // Draw bullets (yellow lines)
             ctx.fillStyle = '#ffff00';
-            if (game.bullets) {
-                game.bullets.forEach(bullet => {
+            (game.ships || []).forEach(ship => {
+                ship.bullets.forEach(bullet => {
                     if (bullet.active) {
                         ctx.fillRect(bullet.position.x + 2, bullet.position.y, 4, 12);
                     }
                 });
+            });
+            
+            // Draw enemy bullets (orange lines)
+            ctx.fillStyle = '#ff8800';
+            if (game.enemy_bullets) {
+                game.enemy_bullets.forEach(bullet => {
+                    if (bullet.active) {
+                        ctx.fillRect(bullet.position.x, bullet.position.y, 4, 10);
+                    }
+                });
             }
             
+            // Draw falling power-ups, lettered by kind
+            ctx.font = 'bold 12px Arial';
+            ctx.textAlign = 'center';
+            (game.power_ups || []).forEach(item => {
+                const style = powerUpStyles[item.kind] || {color: '#ffffff', letter: '?'};
+                ctx.fillStyle = style.color;
+                ctx.fillRect(item.position.x, item.position.y, 16, 16);
+                ctx.fillStyle = '#000000';
+                ctx.fillText(style.letter, item.position.x + 8, item.position.y + 12);
+            });
+            ctx.textAlign = 'left';
+            
             // Draw game borders
             ctx.strokeStyle = '#ffffff';
             ctx.lineWidth = 2;
@@ -304,7 +846,6 @@ func (h *FrontendHandler) Index(w http.ResponseWriter, r *http.Request) {
                 ctx.font = '24px Arial';
                 ctx.fillText('Final Score: ' + game.score + ' | Level: ' + game.level, canvas.width/2, canvas.height/2 + 30);
                 ctx.fillText('Click New Game to restart', canvas.width/2, canvas.height/2 + 60);
-                if (gameLoop) clearInterval(gameLoop);
             }
             
             ctx.textAlign = 'left'; // Reset text alignment
@@ -318,49 +859,218 @@ func (h *FrontendHandler) Index(w http.ResponseWriter, r *http.Request) {
 	w.Write([]byte(html))
 }
 
+// GetSession returns the player behind the session cookie
+func (h *FrontendHandler) GetSession(w http.ResponseWriter, r *http.Request) {
+	player, err := h.sessions.Read(r)
+	if err != nil {
+		writeProblem(w, r, http.StatusNotFound, "no_session", "No session")
+		return
+	}
+	writeJSON(w, player, http.StatusOK)
+}
+
+// CreateSession sets the nickname, issuing a new player id unless the browser
+// already holds a valid session.
+func (h *FrontendHandler) CreateSession(w http.ResponseWriter, r *http.Request) {
+	var req SessionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeProblem(w, r, http.StatusBadRequest, "invalid_body", "Invalid request body")
+		return
+	}
+	name, err := session.NormalizeName(req.Name)
+	if err != nil {
+		writeProblem(w, r, http.StatusBadRequest, "invalid_nickname", "Invalid nickname")
+		return
+	}
+
+	player, err := h.sessions.Read(r)
+	if err != nil {
+		player = session.Player{ID: session.NewPlayerID()}
+		h.logger.WithField("player_id", player.ID).Info("New player session")
+	}
+	player.Name = name
+
+	h.sessions.Write(w, player)
+	writeJSON(w, player, http.StatusOK)
+}
+
 func (h *FrontendHandler) ProxyStartGame(w http.ResponseWriter, r *http.Request) {
-	h.proxyRequest(w, r, "/game/start")
+	h.proxyRequest(w, r, "/v1/game/start")
 }
 
 func (h *FrontendHandler) ProxyMove(w http.ResponseWriter, r *http.Request) {
-	h.proxyRequest(w, r, "/game/move")
+	h.proxyRequest(w, r, "/v1/game/move")
+}
+
+func (h *FrontendHandler) ProxyMoves(w http.ResponseWriter, r *http.Request) {
+	h.proxyRequest(w, r, "/v1/game/moves")
+}
+
+func (h *FrontendHandler) ProxyJoin(w http.ResponseWriter, r *http.Request) {
+	h.proxyRequest(w, r, "/v1/game/join")
+}
+
+func (h *FrontendHandler) ProxyLeave(w http.ResponseWriter, r *http.Request) {
+	h.proxyRequest(w, r, "/v1/game/leave")
+}
+
+func (h *FrontendHandler) ProxyLeaderboard(w http.ResponseWriter, r *http.Request) {
+	h.proxyQuery(w, r, "/v1/leaderboard")
+}
+
+func (h *FrontendHandler) ProxyLeaderboardRank(w http.ResponseWriter, r *http.Request) {
+	h.proxyQuery(w, r, "/v1/leaderboard/rank")
+}
+
+func (h *FrontendHandler) ProxyActiveGame(w http.ResponseWriter, r *http.Request) {
+	h.proxyQuery(w, r, "/v1/game/active")
+}
+
+func (h *FrontendHandler) ProxyHistory(w http.ResponseWriter, r *http.Request) {
+	h.proxyQuery(w, r, "/v1/game/history")
 }
 
 func (h *FrontendHandler) ProxyStatus(w http.ResponseWriter, r *http.Request) {
+	h.proxyQuery(w, r, "/v1/game/status")
+}
+
+// ProxyGameSocket relays the browser's WebSocket to the game service
+func (h *FrontendHandler) ProxyGameSocket(w http.ResponseWriter, r *http.Request) {
+	h.proxySocket(w, r, "/v1/game/ws")
+}
+
+// ProxySpectateSocket relays a read-only spectator WebSocket
+func (h *FrontendHandler) ProxySpectateSocket(w http.ResponseWriter, r *http.Request) {
+	h.proxySocket(w, r, "/v1/game/spectate")
+}
+
+func (h *FrontendHandler) ProxyFeatured(w http.ResponseWriter, r *http.Request) {
+	h.proxyQuery(w, r, "/v1/game/featured")
+}
+
+func (h *FrontendHandler) proxySocket(w http.ResponseWriter, r *http.Request, path string) {
 	gameID := r.URL.Query().Get("game_id")
-	targetURL := h.gameServiceURL + "/game/status?game_id=" + url.QueryEscape(gameID)
-	
-	resp, err := h.client.Get(targetURL)
+	targetURL := strings.Replace(h.gameServiceURL, "http", "ws", 1) + path + "?game_id=" + url.QueryEscape(gameID)
+
+	header := http.Header{}
+	h.forwardIdentity(r, header)
+
+	backend, resp, err := websocket.DefaultDialer.Dial(targetURL, header)
+	if err != nil {
+		if resp != nil {
+			// Surface the game service's rejection (e.g. unknown game) as-is
+			defer resp.Body.Close()
+			copyResponse(w, resp)
+			return
+		}
+		h.logger.WithError(err).Error("Failed to reach game service socket")
+		writeUnavailable(w, r)
+		return
+	}
+	defer backend.Close()
+
+	client, err := upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		h.logger.WithError(err).Warn("WebSocket upgrade failed")
+		return
+	}
+	defer client.Close()
+
+	errc := make(chan error, 2)
+	go relayMessages(backend, client, errc)
+	go relayMessages(client, backend, errc)
+	<-errc
+}
+
+func relayMessages(dst, src *websocket.Conn, errc chan<- error) {
+	for {
+		messageType, message, err := src.ReadMessage()
+		if err != nil {
+			if closeErr, ok := err.(*websocket.CloseError); ok {
+				dst.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(closeErr.Code, closeErr.Text))
+			}
+			errc <- err
+			return
+		}
+		if err := dst.WriteMessage(messageType, message); err != nil {
+			errc <- err
+			return
+		}
+	}
+}
+
+// proxyQuery forwards a GET request with its query string untouched
+func (h *FrontendHandler) proxyQuery(w http.ResponseWriter, r *http.Request, path string) {
+	targetURL := h.gameServiceURL + path
+	if r.URL.RawQuery != "" {
+		targetURL += "?" + r.URL.RawQuery
+	}
+
+	req, err := http.NewRequest(http.MethodGet, targetURL, nil)
+	if err != nil {
+		writeProblem(w, r, http.StatusBadRequest, "bad_request", "Bad request")
+		return
+	}
+	h.forwardIdentity(r, req.Header)
+
+	resp, err := h.client.Do(req)
 	if err != nil {
-		http.Error(w, "Service unavailable", http.StatusServiceUnavailable)
+		writeUnavailable(w, r)
 		return
 	}
 	defer resp.Body.Close()
-	
+
+	copyResponse(w, resp)
+}
+
+// forwardIdentity replaces any identity headers with the signed session's
+// and vouches for them with the service token
+func (h *FrontendHandler) forwardIdentity(r *http.Request, header http.Header) {
+	header.Del(playerIDHeader)
+	header.Del(playerNameHeader)
+	header.Del(serviceTokenHeader)
+	if h.serviceToken != "" {
+		header.Set(serviceTokenHeader, h.serviceToken)
+	}
+
+	player, err := h.sessions.Read(r)
+	if err != nil {
+		if err == session.ErrBadSession {
+			h.logger.Warn("Ignoring session cookie with invalid signature")
+		}
+		return
+	}
+	header.Set(playerIDHeader, player.ID)
+	header.Set(playerNameHeader, player.Name)
+}
+
+func writeJSON(w http.ResponseWriter, data interface{}, status int) {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(resp.StatusCode)
-	io.Copy(w, resp.Body)
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(data)
 }
 
 func (h *FrontendHandler) proxyRequest(w http.ResponseWriter, r *http.Request, path string) {
 	targetURL := h.gameServiceURL + path
+	if r.URL.RawQuery != "" {
+		targetURL += "?" + r.URL.RawQuery
+	}
 	
 	req, err := http.NewRequest(r.Method, targetURL, r.Body)
 	if err != nil {
-		http.Error(w, "Bad request", http.StatusBadRequest)
+		writeProblem(w, r, http.StatusBadRequest, "bad_request", "Bad request")
 		return
 	}
 	
-	req.Header = r.Header
+	req.Header = r.Header.Clone()
+	h.forwardIdentity(r, req.Header)
 	
 	resp, err := h.client.Do(req)
 	if err != nil {
-		http.Error(w, "Service unavailable", http.StatusServiceUnavailable)
+		writeUnavailable(w, r)
 		return
 	}
 	defer resp.Body.Close()
 	
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(resp.StatusCode)
-	io.Copy(w, resp.Body)
+	copyResponse(w, resp)
 }
\ No newline at end of file
@@ -0,0 +1,58 @@
+package handlers
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+)
+
+const (
+	problemContentType = "application/problem+json"
+	problemTypeBase    = "urn:game-service:problem:"
+	requestIDHeader    = "X-Request-ID"
+	ackedSeqHeader     = "X-Acked-Seq"
+)
+
+// problem is an RFC 7807 body for errors raised by the frontend itself. It
+// uses the same shape and code namespace as the game service, whose problem
+// responses are passed through untouched.
+type problem struct {
+	Type     string `json:"type"`
+	Title    string `json:"title"`
+	Status   int    `json:"status"`
+	Instance string `json:"instance,omitempty"`
+	Code     string `json:"code"`
+}
+
+func writeProblem(w http.ResponseWriter, r *http.Request, status int, code, title string) {
+	w.Header().Set("Content-Type", problemContentType)
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(problem{
+		Type:     problemTypeBase + code,
+		Title:    title,
+		Status:   status,
+		Instance: r.URL.Path,
+		Code:     code,
+	})
+}
+
+func writeUnavailable(w http.ResponseWriter, r *http.Request) {
+	writeProblem(w, r, http.StatusServiceUnavailable, "service_unavailable", "Game service unavailable")
+}
+
+// copyResponse relays a game service response, keeping its content type so
+// problem bodies reach the browser as problem+json
+func copyResponse(w http.ResponseWriter, resp *http.Response) {
+	contentType := resp.Header.Get("Content-Type")
+	if contentType == "" {
+		contentType = "application/json"
+	}
+	w.Header().Set("Content-Type", contentType)
+	for _, header := range []string{requestIDHeader, ackedSeqHeader} {
+		if value := resp.Header.Get(header); value != "" {
+			w.Header().Set(header, value)
+		}
+	}
+	w.WriteHeader(resp.StatusCode)
+	io.Copy(w, resp.Body)
+}
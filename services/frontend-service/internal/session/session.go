@@ -0,0 +1,127 @@
+package session
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+	"unicode"
+)
+
+const (
+	CookieName = "player_session"
+	// Sessions are long lived; the cookie only carries a nickname
+	cookieMaxAge = 365 * 24 * time.Hour
+	maxNameRunes = 20
+)
+
+var (
+	ErrNoSession   = errors.New("no session")
+	ErrBadSession  = errors.New("invalid session signature")
+	ErrInvalidName = errors.New("invalid nickname")
+)
+
+// Player is the identity carried by the session cookie
+type Player struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// Signer issues and checks HMAC-signed session cookies. The cookie value is
+// id.base64(name).signature, so no server-side session storage is needed.
+type Signer struct {
+	secret []byte
+	secure bool
+}
+
+func NewSigner(secret []byte, secure bool) *Signer {
+	return &Signer{secret: secret, secure: secure}
+}
+
+// NewSecret returns a random key for deployments that don't configure one.
+// Sessions signed with it do not survive a restart.
+func NewSecret() []byte {
+	secret := make([]byte, 32)
+	rand.Read(secret)
+	return secret
+}
+
+func NewPlayerID() string {
+	id := make([]byte, 16)
+	rand.Read(id)
+	return hex.EncodeToString(id)
+}
+
+// NormalizeName trims the nickname and enforces the same rules as the game
+// service: 1-20 letters, digits, spaces, dashes and underscores.
+func NormalizeName(name string) (string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" || len([]rune(name)) > maxNameRunes {
+		return "", ErrInvalidName
+	}
+	for _, r := range name {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != ' ' && r != '-' && r != '_' {
+			return "", ErrInvalidName
+		}
+	}
+	return name, nil
+}
+
+// Read returns the player from the request's session cookie
+func (s *Signer) Read(r *http.Request) (Player, error) {
+	cookie, err := r.Cookie(CookieName)
+	if err != nil {
+		return Player{}, ErrNoSession
+	}
+	return s.decode(cookie.Value)
+}
+
+// Write sets the signed session cookie for player
+func (s *Signer) Write(w http.ResponseWriter, player Player) {
+	http.SetCookie(w, &http.Cookie{
+		Name:     CookieName,
+		Value:    s.encode(player),
+		Path:     "/",
+		MaxAge:   int(cookieMaxAge.Seconds()),
+		HttpOnly: true,
+		Secure:   s.secure,
+		SameSite: http.SameSiteLaxMode,
+	})
+}
+
+func (s *Signer) encode(player Player) string {
+	payload := player.ID + "." + base64.RawURLEncoding.EncodeToString([]byte(player.Name))
+	return payload + "." + s.sign(payload)
+}
+
+func (s *Signer) decode(value string) (Player, error) {
+	i := strings.LastIndexByte(value, '.')
+	if i < 0 {
+		return Player{}, ErrBadSession
+	}
+	payload, signature := value[:i], value[i+1:]
+	if !hmac.Equal([]byte(signature), []byte(s.sign(payload))) {
+		return Player{}, ErrBadSession
+	}
+
+	id, encodedName, ok := strings.Cut(payload, ".")
+	if !ok || id == "" {
+		return Player{}, ErrBadSession
+	}
+	name, err := base64.RawURLEncoding.DecodeString(encodedName)
+	if err != nil {
+		return Player{}, ErrBadSession
+	}
+	return Player{ID: id, Name: string(name)}, nil
+}
+
+func (s *Signer) sign(payload string) string {
+	mac := hmac.New(sha256.New, s.secret)
+	mac.Write([]byte(payload))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
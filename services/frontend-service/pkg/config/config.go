@@ -6,6 +6,9 @@ type Config struct {
 	Port           string
 	LogLevel       string
 	GameServiceURL string
+	SessionSecret  string
+	ServiceToken   string
+	SecureCookies  bool
 }
 
 func Load() *Config {
@@ -13,6 +16,9 @@ func Load() *Config {
 		Port:           getEnv("PORT", "8080"),
 		LogLevel:       getEnv("LOG_LEVEL", "info"),
 		GameServiceURL: getEnv("GAME_SERVICE_URL", "http://localhost:8081"),
+		SessionSecret:  getEnv("SESSION_SECRET", ""),
+		ServiceToken:   getEnv("SERVICE_TOKEN", ""),
+		SecureCookies:  getEnv("SECURE_COOKIES", "false") == "true",
 	}
 }
 
@@ -2,20 +2,29 @@ package main
 
 import (
 	"context"
+	"net"
 	"net/http"
 	"os"
 	"os/signal"
 	"syscall"
 	"time"
 
+	"portfolio-game-service/internal/domain"
+	"portfolio-game-service/internal/games"
 	"portfolio-game-service/internal/handlers"
+	"portfolio-game-service/internal/leaderboard"
+	"portfolio-game-service/internal/levels"
+	"portfolio-game-service/internal/repository"
+	"portfolio-game-service/internal/rpc"
 	"portfolio-game-service/internal/services"
 	"portfolio-game-service/pkg/config"
+	"portfolio-game-service/pkg/gamepb"
 	"portfolio-game-service/pkg/logger"
 	"portfolio-game-service/pkg/metrics"
 
 	"github.com/gorilla/mux"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"google.golang.org/grpc"
 )
 
 func main() {
@@ -23,30 +32,97 @@ func main() {
 	log := logger.New(cfg.LogLevel)
 	
 	metrics.Init()
-	gameService := services.NewGameService(log)
+
+	var levelSet *domain.LevelSet
+	var err error
+	if cfg.LevelsDir != "" {
+		levelSet, err = levels.LoadDir(cfg.LevelsDir)
+	} else {
+		levelSet, err = levels.Default()
+	}
+	if err != nil {
+		log.WithError(err).WithField("levels_dir", cfg.LevelsDir).Fatal("Failed to load level definitions")
+	}
+	log.WithField("levels", levelSet.Len()).Info("Level definitions loaded")
+
+	repo, err := repository.Open(cfg.StorageBackend, cfg.StoragePath, log)
+	if err != nil {
+		log.WithError(err).Fatal("Failed to open game repository")
+	}
+	scores, err := leaderboard.Open(cfg.StorageBackend, cfg.ScoresPath, log)
+	if err != nil {
+		log.WithError(err).Fatal("Failed to open leaderboard")
+	}
+
+	env := games.Env{Levels: levelSet}
+	if cfg.DailyWord != "" {
+		word, ok := domain.NormalizeWord(cfg.DailyWord)
+		if !ok {
+			log.WithField("daily_word", cfg.DailyWord).Fatal("Invalid DAILY_WORD, expected five letters A-Z")
+		}
+		env.DailyWord = word
+	}
+
+	gameService, err := services.NewGameService(repo, scores, env, log, time.Second/time.Duration(cfg.TickRate), cfg.PersistPeriod, services.Retention{
+		IdleTTL:       cfg.GameIdleTTL,
+		FinishedGrace: cfg.FinishedGrace,
+		Interval:      cfg.JanitorPeriod,
+	})
+	if err != nil {
+		log.WithError(err).Fatal("Failed to restore games")
+	}
 	gameHandler := handlers.NewGameHandler(gameService, log)
+	leaderboardHandler := handlers.NewLeaderboardHandler(scores, log)
+
+	wordleHandler := handlers.NewWordleHandler(gameService, log)
 
 	r := mux.NewRouter()
+	r.NotFoundHandler = handlers.NotFound(log)
+	r.MethodNotAllowedHandler = handlers.MethodNotAllowed(log)
 	
 	// Health endpoint
 	r.HandleFunc("/health", gameHandler.Health).Methods("GET")
 	
-	// Game endpoints
-	r.HandleFunc("/game/start", gameHandler.StartGame).Methods("POST")
-	r.HandleFunc("/game/move", gameHandler.MakeMove).Methods("POST")
-	r.HandleFunc("/game/status", gameHandler.GetStatus).Methods("GET")
-	
+	// API documentation
+	r.HandleFunc("/openapi.json", handlers.OpenAPI).Methods("GET")
+
+	// Versioned API, with the original unversioned paths kept as aliases
+	registerAPI(r.PathPrefix("/v1").Subrouter(), gameHandler, wordleHandler, leaderboardHandler)
+	registerAPI(r, gameHandler, wordleHandler, leaderboardHandler)
+
 	// Metrics endpoint
 	r.Handle("/metrics", promhttp.Handler())
 
+	// Player ids are trusted as sent, so only the frontend, which holds the
+	// token, may send them
+	var grpcOptions []grpc.ServerOption
+	if cfg.ServiceToken != "" {
+		unary, stream := rpc.ServiceTokenInterceptors(cfg.ServiceToken)
+		grpcOptions = append(grpcOptions, grpc.UnaryInterceptor(unary), grpc.StreamInterceptor(stream))
+	} else {
+		log.Warn("SERVICE_TOKEN not set, any caller that reaches the service can act as any player")
+	}
+
 	srv := &http.Server{
 		Addr:         ":" + cfg.Port,
-		Handler:      r,
+		Handler:      handlers.RequestID(handlers.RequireServiceToken(cfg.ServiceToken, log, r)),
 		ReadTimeout:  15 * time.Second,
 		WriteTimeout: 15 * time.Second,
 		IdleTimeout:  60 * time.Second,
 	}
 
+	// The gRPC API listens on its own port next to REST
+	grpcServer := grpc.NewServer(grpcOptions...)
+	gamepb.RegisterGameServiceServer(grpcServer, rpc.NewGameServer(gameService, log))
+
+	// Server-authoritative simulation clock
+	loopCtx, stopLoop := context.WithCancel(context.Background())
+	loopDone := make(chan struct{})
+	go func() {
+		gameService.Run(loopCtx)
+		close(loopDone)
+	}()
+
 	go func() {
 		log.WithField("port", cfg.Port).Info("Starting game service")
 		if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
@@ -54,17 +130,76 @@ func main() {
 		}
 	}()
 
+	go func() {
+		listener, err := net.Listen("tcp", ":"+cfg.GRPCPort)
+		if err != nil {
+			log.WithError(err).Fatal("gRPC server failed to listen")
+		}
+		log.WithField("port", cfg.GRPCPort).Info("Starting gRPC server")
+		if err := grpcServer.Serve(listener); err != nil {
+			log.WithError(err).Fatal("gRPC server failed")
+		}
+	}()
+
 	// Graceful shutdown
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
 	<-quit
 
 	log.Info("Shutting down server...")
+	stopLoop()
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
 
 	if err := srv.Shutdown(ctx); err != nil {
 		log.WithError(err).Fatal("Server forced to shutdown")
 	}
+
+	// Open Subscribe streams only end with their game, so give in-flight calls
+	// a few seconds and then cut the rest off
+	grpcStopped := make(chan struct{})
+	go func() {
+		grpcServer.GracefulStop()
+		close(grpcStopped)
+	}()
+	select {
+	case <-grpcStopped:
+	case <-time.After(5 * time.Second):
+		grpcServer.Stop()
+	}
+	<-loopDone
+	if err := repo.Close(); err != nil {
+		log.WithError(err).Error("Failed to close game repository")
+	}
+	if err := scores.Close(); err != nil {
+		log.WithError(err).Error("Failed to close leaderboard")
+	}
 	log.Info("Server exited")
-}
\ No newline at end of file
+}
+
+// registerAPI mounts the game and leaderboard endpoints on r
+func registerAPI(r *mux.Router, gameHandler *handlers.GameHandler, wordleHandler *handlers.WordleHandler, leaderboardHandler *handlers.LeaderboardHandler) {
+	// Game endpoints
+	r.HandleFunc("/game/start", gameHandler.ValidateStart(gameHandler.StartGame)).Methods("POST")
+	r.HandleFunc("/game/move", gameHandler.ValidateMove(gameHandler.MakeMove)).Methods("POST")
+	r.HandleFunc("/game/moves", gameHandler.ValidateMoves(gameHandler.MakeMoves)).Methods("POST")
+	r.HandleFunc("/game/join", gameHandler.ValidateSeat(gameHandler.JoinGame)).Methods("POST")
+	r.HandleFunc("/game/leave", gameHandler.ValidateSeat(gameHandler.LeaveGame)).Methods("POST")
+	r.HandleFunc("/game/status", gameHandler.GetStatus).Methods("GET")
+	r.HandleFunc("/game/active", gameHandler.ActiveGame).Methods("GET")
+	r.HandleFunc("/game/history", gameHandler.History).Methods("GET")
+	r.HandleFunc("/game/ws", gameHandler.GameSocket).Methods("GET")
+	r.HandleFunc("/game/spectate", gameHandler.SpectateSocket).Methods("GET")
+	r.HandleFunc("/game/featured", gameHandler.FeaturedGame).Methods("GET")
+	r.HandleFunc("/game/replay", gameHandler.GetReplay).Methods("GET")
+	r.HandleFunc("/game/replay/verify", gameHandler.VerifyReplay).Methods("GET", "POST")
+
+	// Wordle endpoints
+	r.HandleFunc("/game/wordle/start", wordleHandler.ValidateStart(wordleHandler.StartGame)).Methods("POST")
+	r.HandleFunc("/game/wordle/guess", wordleHandler.ValidateGuess(wordleHandler.Guess)).Methods("POST")
+	r.HandleFunc("/game/wordle/status", wordleHandler.GetStatus).Methods("GET")
+
+	// Leaderboard endpoints
+	r.HandleFunc("/leaderboard", leaderboardHandler.Top).Methods("GET")
+	r.HandleFunc("/leaderboard/rank", leaderboardHandler.Rank).Methods("GET")
+}
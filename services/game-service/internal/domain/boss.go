@@ -0,0 +1,189 @@
+package domain
+
+import "fmt"
+
+// Boss attacks. Boss bullets fall straight down like any enemy bullet; the
+// attacks differ in how many are fired at once across the boss's width.
+const (
+	BossAttackSingle  = "single"
+	BossAttackSpread  = "spread"
+	BossAttackBarrage = "barrage"
+)
+
+var bossAttackBullets = map[string]int{
+	BossAttackSingle:  1,
+	BossAttackSpread:  3,
+	BossAttackBarrage: 5,
+}
+
+const (
+	bossWidth  = 120
+	bossHeight = 60
+	bossTop    = 60
+)
+
+// BossDef describes the boss that replaces the wave of every Every-th level.
+// Each boss has HPStep more hit points than the one before. Phases run in
+// order as the boss is worn down.
+type BossDef struct {
+	Every  int         `json:"every" yaml:"every"`
+	HP     int         `json:"hp" yaml:"hp"`
+	HPStep int         `json:"hp_step" yaml:"hp_step"`
+	Reward int         `json:"reward" yaml:"reward"`
+	Phases []BossPhase `json:"phases" yaml:"phases"`
+}
+
+// BossPhase is how the boss fights while its health is at or below Health
+// percent, until the next phase takes over
+type BossPhase struct {
+	Health       int    `json:"health" yaml:"health"`
+	Attack       string `json:"attack" yaml:"attack"`
+	FireInterval int    `json:"fire_interval" yaml:"fire_interval"`
+	Speed        int    `json:"speed" yaml:"speed"`
+}
+
+// Validate returns every problem found in the definition, not just the first
+func (d BossDef) Validate() []error {
+	var errs []error
+	fail := func(field, format string, args ...interface{}) {
+		errs = append(errs, fmt.Errorf("%s: %s", field, fmt.Sprintf(format, args...)))
+	}
+
+	if d.Every < 1 {
+		fail("every", "must be at least 1, got %d", d.Every)
+	}
+	if d.HP < 1 {
+		fail("hp", "must be at least 1, got %d", d.HP)
+	}
+	if d.HPStep < 0 {
+		fail("hp_step", "must not be negative, got %d", d.HPStep)
+	}
+	if d.Reward < 0 {
+		fail("reward", "must not be negative, got %d", d.Reward)
+	}
+	if len(d.Phases) == 0 {
+		fail("phases", "a boss needs at least one phase")
+	}
+	for i, phase := range d.Phases {
+		field := fmt.Sprintf("phases[%d]", i)
+		switch {
+		case i == 0 && phase.Health != 100:
+			fail(field+".health", "the first phase must start at 100, got %d", phase.Health)
+		case i > 0 && (phase.Health < 1 || phase.Health >= d.Phases[i-1].Health):
+			fail(field+".health", "must be from 1 to below the previous phase's %d, got %d", d.Phases[i-1].Health, phase.Health)
+		}
+		if _, ok := bossAttackBullets[phase.Attack]; !ok {
+			fail(field+".attack", "unknown attack %q, expected single, spread or barrage", phase.Attack)
+		}
+		if phase.FireInterval < 1 {
+			fail(field+".fire_interval", "must be at least 1 tick, got %d", phase.FireInterval)
+		}
+		if phase.Speed < 0 {
+			fail(field+".speed", "must not be negative, got %d", phase.Speed)
+		}
+	}
+	return errs
+}
+
+// hp is the health of the boss guarding level number
+func (d BossDef) hp(number int) int {
+	return d.HP + (number/d.Every-1)*d.HPStep
+}
+
+// Boss is the single large enemy of a boss level. Position is its top-left
+// corner and Phase indexes the stage's boss phases.
+type Boss struct {
+	GameObject
+	HP        int `json:"hp"`
+	MaxHP     int `json:"max_hp"`
+	Phase     int `json:"phase"`
+	Direction int `json:"direction"`
+}
+
+// spawnBoss puts the stage's boss in the middle of the top of the field
+func (g *Game) spawnBoss() {
+	hp := g.Stage.Boss.hp(g.Stage.Number)
+	g.Boss = &Boss{
+		GameObject: GameObject{
+			ID:       g.newEntityID(entityBoss),
+			Position: Position{X: (g.Stage.World.Width - bossWidth) / 2, Y: bossTop},
+			Active:   true,
+		},
+		HP:        hp,
+		MaxHP:     hp,
+		Direction: 1,
+	}
+}
+
+func (g *Game) bossPhase() BossPhase {
+	return g.Stage.Boss.Phases[g.Boss.Phase]
+}
+
+// moveBoss sweeps the boss from side to side at its phase's speed
+func (g *Game) moveBoss() {
+	if g.Boss == nil {
+		return
+	}
+	x := g.Boss.Position.X + g.Boss.Direction*g.bossPhase().Speed
+	if x < 0 || x+bossWidth > g.Stage.World.Width {
+		g.Boss.Direction = -g.Boss.Direction
+		return
+	}
+	g.Boss.Position.X = x
+}
+
+// bossFire launches the phase's attack every FireInterval ticks, its bullets
+// spaced evenly along the boss's underside
+func (g *Game) bossFire() {
+	if g.Boss == nil {
+		return
+	}
+	phase := g.bossPhase()
+	if g.Tick%uint64(phase.FireInterval) != 0 {
+		return
+	}
+
+	bullets := bossAttackBullets[phase.Attack]
+	for i := 0; i < bullets; i++ {
+		g.EnemyBullets = append(g.EnemyBullets, GameObject{
+			ID:       g.newEntityID(entityEnemyBullet),
+			Position: Position{X: g.Boss.Position.X + (i+1)*bossWidth/(bullets+1), Y: g.Boss.Position.Y + bossHeight},
+			Active:   true,
+		})
+	}
+}
+
+// bossHitBy reports whether bullet struck the boss
+func (g *Game) bossHitBy(bullet GameObject) bool {
+	if g.Boss == nil {
+		return false
+	}
+	x, y := bullet.Position.X+bulletHalfWidth, bullet.Position.Y
+	return x >= g.Boss.Position.X && x < g.Boss.Position.X+bossWidth &&
+		y >= g.Boss.Position.Y && y < g.Boss.Position.Y+bossHeight
+}
+
+// damageBoss takes a hit point from the boss and moves it on to the phase
+// its health calls for. The ship that lands the final hit earns the reward.
+func (g *Game) damageBoss(ship *Ship) {
+	g.Boss.HP--
+	if g.Boss.HP <= 0 {
+		ship.Score += g.Stage.Boss.Reward
+		g.Score += g.Stage.Boss.Reward
+		g.Boss = nil
+		return
+	}
+
+	phases := g.Stage.Boss.Phases
+	for g.Boss.Phase+1 < len(phases) && g.Boss.HP*100 <= phases[g.Boss.Phase+1].Health*g.Boss.MaxHP {
+		g.Boss.Phase++
+	}
+}
+
+func cloneBoss(boss *Boss) *Boss {
+	if boss == nil {
+		return nil
+	}
+	clone := *boss
+	return &clone
+}
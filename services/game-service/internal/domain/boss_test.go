@@ -0,0 +1,99 @@
+package domain
+
+import "testing"
+
+// bossLevels is testLevels with the default boss: every fifth level, 40 hit
+// points and 20 more each time it returns
+func bossLevels(t *testing.T) *LevelSet {
+	t.Helper()
+	levels := testLevels(t)
+	levels.boss = &BossDef{
+		Every:  5,
+		HP:     40,
+		HPStep: 20,
+		Reward: 500,
+		Phases: []BossPhase{
+			{Health: 100, Attack: BossAttackSingle, FireInterval: 20, Speed: 3},
+			{Health: 60, Attack: BossAttackSpread, FireInterval: 16, Speed: 4},
+			{Health: 25, Attack: BossAttackBarrage, FireInterval: 12, Speed: 6},
+		},
+	}
+	return levels
+}
+
+func TestBossLevels(t *testing.T) {
+	tests := []struct {
+		level int
+		hp    int
+	}{
+		{level: 4},
+		{level: 5, hp: 40},
+		{level: 6},
+		{level: 10, hp: 60},
+		{level: 15, hp: 80},
+	}
+
+	for _, tt := range tests {
+		levels := bossLevels(t)
+		g := NewGame("boss", 1, levels)
+		g.startLevel(levels.Level(tt.level))
+
+		switch {
+		case tt.hp == 0 && g.Boss != nil:
+			t.Errorf("level %d has a boss", tt.level)
+		case tt.hp != 0 && g.Boss == nil:
+			t.Errorf("level %d has no boss", tt.level)
+		case tt.hp != 0 && (g.Boss.HP != tt.hp || g.Boss.MaxHP != tt.hp):
+			t.Errorf("level %d boss has %d of %d hp, want %d", tt.level, g.Boss.HP, g.Boss.MaxHP, tt.hp)
+		}
+	}
+}
+
+func TestDamageBossPhases(t *testing.T) {
+	// The first boss has 40 hp; phases start at 60% (24 hp) and 25% (10 hp)
+	tests := []struct {
+		hits  int
+		hp    int
+		phase int
+	}{
+		{hits: 1, hp: 39, phase: 0},
+		{hits: 15, hp: 25, phase: 0},
+		{hits: 16, hp: 24, phase: 1},
+		{hits: 29, hp: 11, phase: 1},
+		{hits: 30, hp: 10, phase: 2},
+		{hits: 39, hp: 1, phase: 2},
+	}
+
+	for _, tt := range tests {
+		levels := bossLevels(t)
+		g := NewGame("boss", 1, levels)
+		g.startLevel(levels.Level(5))
+		for i := 0; i < tt.hits; i++ {
+			g.damageBoss(&g.Ships[0])
+		}
+
+		if g.Boss.HP != tt.hp || g.Boss.Phase != tt.phase {
+			t.Errorf("after %d hits boss has %d hp in phase %d, want %d hp in phase %d", tt.hits, g.Boss.HP, g.Boss.Phase, tt.hp, tt.phase)
+		}
+		if attack := g.bossPhase().Attack; attack != levels.boss.Phases[tt.phase].Attack {
+			t.Errorf("after %d hits boss attacks with %s", tt.hits, attack)
+		}
+	}
+}
+
+func TestFinalHitOnBossEarnsReward(t *testing.T) {
+	levels := bossLevels(t)
+	g := NewGame("boss", 1, levels)
+	g.startLevel(levels.Level(5))
+	g.Boss.HP = 1
+	score := g.Score
+
+	ship := &g.Ships[0]
+	g.damageBoss(ship)
+	if g.Boss != nil {
+		t.Fatal("boss survived its last hit point")
+	}
+	if ship.Score != 500 || g.Score != score+500 {
+		t.Errorf("ship score %d and game score up %d after the kill, want the 500 reward on both", ship.Score, g.Score-score)
+	}
+}
@@ -0,0 +1,171 @@
+package domain
+
+import "slices"
+
+const (
+	// Hits a bunker cell takes before it is gone
+	bunkerCellStrength = 2
+	// Bullets are 4 px wide and strike bunkers along their centre line
+	bulletHalfWidth = 2
+	enemyHeight     = 20
+)
+
+// BunkerLayout places Count identical bunkers evenly across the world with
+// their tops at Y. Each is a grid of Columns by Rows square cells, CellSize
+// pixels wide. Bunkers are rebuilt whole at the start of every level unless
+// Persist is set and the previous level left bunkers of the same layout, in
+// which case their damage carries over.
+type BunkerLayout struct {
+	Count    int  `json:"count" yaml:"count"`
+	Y        int  `json:"y" yaml:"y"`
+	Columns  int  `json:"columns" yaml:"columns"`
+	Rows     int  `json:"rows" yaml:"rows"`
+	CellSize int  `json:"cell_size" yaml:"cell_size"`
+	Persist  bool `json:"persist,omitempty" yaml:"persist,omitempty"`
+}
+
+// positions spreads the bunkers across world with equal gaps around them
+func (l BunkerLayout) positions(world World) []Position {
+	width := l.Columns * l.CellSize
+	gap := (world.Width - l.Count*width) / (l.Count + 1)
+	positions := make([]Position, l.Count)
+	for i := range positions {
+		positions[i] = Position{X: gap + i*(width+gap), Y: l.Y}
+	}
+	return positions
+}
+
+// Bunker is cover between the ships and the wave. Position is its top-left
+// corner and Cells the strength left in each cell, row by row from the top;
+// a cell at 0 is destroyed and lets bullets through.
+type Bunker struct {
+	ID       string   `json:"id"`
+	Position Position `json:"position"`
+	CellSize int      `json:"cell_size"`
+	Cells    [][]int  `json:"cells"`
+}
+
+// placeBunkers builds the level's bunkers, or keeps the damaged ones when
+// the layout allows it
+func (g *Game) placeBunkers(layout *BunkerLayout) {
+	if layout == nil {
+		g.Bunkers = make([]Bunker, 0)
+		return
+	}
+
+	positions := layout.positions(g.Stage.World)
+	if layout.Persist && g.bunkersMatch(*layout, positions) {
+		return
+	}
+
+	g.Bunkers = make([]Bunker, 0, len(positions))
+	for _, pos := range positions {
+		cells := make([][]int, layout.Rows)
+		for row := range cells {
+			cells[row] = make([]int, layout.Columns)
+			for col := range cells[row] {
+				cells[row][col] = bunkerCellStrength
+			}
+		}
+		g.Bunkers = append(g.Bunkers, Bunker{
+			ID:       g.newEntityID(entityBunker),
+			Position: pos,
+			CellSize: layout.CellSize,
+			Cells:    cells,
+		})
+	}
+}
+
+// bunkersMatch reports whether the current bunkers have the given layout
+func (g *Game) bunkersMatch(layout BunkerLayout, positions []Position) bool {
+	if len(g.Bunkers) != len(positions) {
+		return false
+	}
+	for i, bunker := range g.Bunkers {
+		if bunker.Position != positions[i] || bunker.CellSize != layout.CellSize ||
+			len(bunker.Cells) != layout.Rows || len(bunker.Cells[0]) != layout.Columns {
+			return false
+		}
+	}
+	return true
+}
+
+// bunkerHit erodes the first intact cell a bullet meets travelling along
+// column x from y to toY and reports whether one stopped it
+func (g *Game) bunkerHit(x, y, toY int) bool {
+	x += bulletHalfWidth
+	for i := range g.Bunkers {
+		bunker := &g.Bunkers[i]
+		if x < bunker.Position.X || len(bunker.Cells) == 0 {
+			continue
+		}
+		col := (x - bunker.Position.X) / bunker.CellSize
+		if col >= len(bunker.Cells[0]) {
+			continue
+		}
+
+		rows := len(bunker.Cells)
+		for n := 0; n < rows; n++ {
+			// Bullets flying up meet the bottom row first
+			row := n
+			if toY < y {
+				row = rows - 1 - n
+			}
+			top := bunker.Position.Y + row*bunker.CellSize
+			if top > max(y, toY) || top+bunker.CellSize <= min(y, toY) || bunker.Cells[row][col] == 0 {
+				continue
+			}
+			bunker.Cells[row][col]--
+			return true
+		}
+	}
+	return false
+}
+
+// enemiesCrushBunkers destroys every cell an enemy of the wave overlaps
+func (g *Game) enemiesCrushBunkers() {
+	for _, enemy := range g.Enemies {
+		if !enemy.Active {
+			continue
+		}
+		for i := range g.Bunkers {
+			bunker := &g.Bunkers[i]
+			for row := range bunker.Cells {
+				top := bunker.Position.Y + row*bunker.CellSize
+				if top >= enemy.Position.Y+enemyHeight || top+bunker.CellSize <= enemy.Position.Y {
+					continue
+				}
+				for col := range bunker.Cells[row] {
+					left := bunker.Position.X + col*bunker.CellSize
+					if left < enemy.Position.X+enemyWidth && left+bunker.CellSize > enemy.Position.X {
+						bunker.Cells[row][col] = 0
+					}
+				}
+			}
+		}
+	}
+}
+
+func cloneBunkers(bunkers []Bunker) []Bunker {
+	clone := make([]Bunker, len(bunkers))
+	for i, bunker := range bunkers {
+		clone[i] = bunker
+		clone[i].Cells = make([][]int, len(bunker.Cells))
+		for row, cells := range bunker.Cells {
+			clone[i].Cells[row] = append([]int(nil), cells...)
+		}
+	}
+	return clone
+}
+
+func bunkersEqual(a, b Bunker) bool {
+	if a.ID != b.ID || a.Position != b.Position || a.CellSize != b.CellSize || len(a.Cells) != len(b.Cells) {
+		return false
+	}
+	for row := range a.Cells {
+		if !slices.Equal(a.Cells[row], b.Cells[row]) {
+			return false
+		}
+	}
+	return true
+}
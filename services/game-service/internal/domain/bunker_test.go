@@ -0,0 +1,127 @@
+package domain
+
+import (
+	"slices"
+	"testing"
+)
+
+// bunkerGame has one bunker of 3 rows by 4 cells, 10 px square, with its
+// top-left corner at (100, 400)
+func bunkerGame(t *testing.T, cells [][]int) *Game {
+	t.Helper()
+	g := NewGame("bunker", 1, testLevels(t))
+	g.Bunkers = []Bunker{{ID: "bunker", Position: Position{X: 100, Y: 400}, CellSize: 10, Cells: cells}}
+	return g
+}
+
+func intact() [][]int {
+	return [][]int{{2, 2, 2, 2}, {2, 2, 2, 2}, {2, 2, 2, 2}}
+}
+
+func TestBunkerHit(t *testing.T) {
+	// A bullet whose left edge is at 111 flies down the centre of column 1
+	const column1 = 111
+	tests := []struct {
+		name     string
+		cells    [][]int
+		x, y, to int
+		stopped  bool
+		want     [][]int
+	}{
+		{
+			name: "flying up meets the bottom row", cells: intact(), x: column1, y: 450, to: 380, stopped: true,
+			want: [][]int{{2, 2, 2, 2}, {2, 2, 2, 2}, {2, 1, 2, 2}},
+		},
+		{
+			name: "flying down meets the top row", cells: intact(), x: column1, y: 380, to: 450, stopped: true,
+			want: [][]int{{2, 1, 2, 2}, {2, 2, 2, 2}, {2, 2, 2, 2}},
+		},
+		{
+			name: "passes a destroyed cell to the next", x: column1, y: 450, to: 380, stopped: true,
+			cells: [][]int{{2, 2, 2, 2}, {2, 1, 2, 2}, {2, 0, 2, 2}},
+			want:  [][]int{{2, 2, 2, 2}, {2, 0, 2, 2}, {2, 0, 2, 2}},
+		},
+		{
+			name: "through a hole", x: column1, y: 450, to: 380,
+			cells: [][]int{{2, 0, 2, 2}, {2, 0, 2, 2}, {2, 0, 2, 2}},
+			want:  [][]int{{2, 0, 2, 2}, {2, 0, 2, 2}, {2, 0, 2, 2}},
+		},
+		{name: "left of the bunker", cells: intact(), x: 90, y: 450, to: 380, want: intact()},
+		{name: "right of the bunker", cells: intact(), x: 140, y: 450, to: 380, want: intact()},
+		{name: "short of the bunker", cells: intact(), x: column1, y: 450, to: 440, want: intact()},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := bunkerGame(t, tt.cells)
+			if stopped := g.bunkerHit(tt.x, tt.y, tt.to); stopped != tt.stopped {
+				t.Errorf("stopped = %v, want %v", stopped, tt.stopped)
+			}
+			if got := g.Bunkers[0].Cells; !slices.EqualFunc(got, tt.want, slices.Equal[[]int]) {
+				t.Errorf("cells = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEnemiesCrushBunkers(t *testing.T) {
+	tests := []struct {
+		name  string
+		enemy GameObject
+		want  [][]int
+	}{
+		{
+			// 25 px wide and 20 tall, it reaches into columns 0-2 of every row
+			name:  "enemy over the corner",
+			enemy: GameObject{Position: Position{X: 100, Y: 405}, Active: true},
+			want:  [][]int{{0, 0, 0, 2}, {0, 0, 0, 2}, {0, 0, 0, 2}},
+		},
+		{
+			name:  "enemy above",
+			enemy: GameObject{Position: Position{X: 100, Y: 380}, Active: true},
+			want:  intact(),
+		},
+		{
+			name:  "destroyed enemy",
+			enemy: GameObject{Position: Position{X: 100, Y: 405}},
+			want:  intact(),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := bunkerGame(t, intact())
+			g.Enemies = []GameObject{tt.enemy}
+			g.enemiesCrushBunkers()
+			if got := g.Bunkers[0].Cells; !slices.EqualFunc(got, tt.want, slices.Equal[[]int]) {
+				t.Errorf("cells = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPlaceBunkersBetweenLevels(t *testing.T) {
+	tests := []struct {
+		name    string
+		change  func(layout *BunkerLayout)
+		damaged bool
+	}{
+		{name: "rebuilt by default", change: func(layout *BunkerLayout) {}},
+		{name: "persisted", change: func(layout *BunkerLayout) { layout.Persist = true }, damaged: true},
+		{name: "persisted with a new layout", change: func(layout *BunkerLayout) { layout.Persist = true; layout.Columns++ }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewGame("levels", 1, testLevels(t))
+			g.Bunkers[0].Cells[0][0] = 0
+
+			layout := *g.Stage.Bunkers
+			tt.change(&layout)
+			g.placeBunkers(&layout)
+			if damaged := g.Bunkers[0].Cells[0][0] == 0; damaged != tt.damaged {
+				t.Errorf("damage kept = %v, want %v", damaged, tt.damaged)
+			}
+		})
+	}
+}
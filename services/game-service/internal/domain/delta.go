@@ -0,0 +1,206 @@
+package domain
+
+import "slices"
+
+// Frame types sent to clients. A state frame carries the whole game and is
+// the keyframe every delta chain starts from.
+const (
+	FrameState = "state"
+	FrameDelta = "delta"
+)
+
+// Frame is one state update for a client. Seq is the tick the frame brings
+// the client to, so a delta whose BaseSeq differs from the client's current
+// Seq means frames were missed and a new keyframe is needed.
+type Frame struct {
+	Type  string `json:"type"`
+	Seq   uint64 `json:"seq"`
+	Game  *Game  `json:"game,omitempty"`
+	Delta *Delta `json:"delta,omitempty"`
+	// You is the receiving player's seat. Frames are shared between clients,
+	// so it is set on each client's copy of the Frame, never on Game.
+	You *You `json:"you,omitempty"`
+}
+
+// Delta is the change from the game at BaseSeq to the game at Seq. Objects
+// are keyed by ID: Ships, Enemies, PowerUps, Bunkers and the bullet lists
+// hold the ones that appeared or changed and Removed names the ones gone
+// since the base. A damaged bunker is sent whole. Ships are sent without
+// their bullets, which travel in Bullets instead. Scalar fields, and the boss
+// while there is one, are always sent; Seats only when they changed.
+type Delta struct {
+	BaseSeq      uint64       `json:"base_seq"`
+	Seq          uint64       `json:"seq"`
+	Status       GameStatus   `json:"status"`
+	Score        int          `json:"score"`
+	Level        int          `json:"level"`
+	Lives        int          `json:"lives"`
+	Formation    Formation    `json:"formation"`
+	Boss         *Boss        `json:"boss,omitempty"`
+	Flags        []string     `json:"flags,omitempty"`
+	Seats        []Seat       `json:"seats,omitempty"`
+	Ships        []Ship       `json:"ships,omitempty"`
+	Enemies      []GameObject `json:"enemies,omitempty"`
+	Bullets      []ShipBullet `json:"bullets,omitempty"`
+	EnemyBullets []GameObject `json:"enemy_bullets,omitempty"`
+	PowerUps     []PowerUp    `json:"power_ups,omitempty"`
+	Bunkers      []Bunker     `json:"bunkers,omitempty"`
+	Removed      []string     `json:"removed,omitempty"`
+}
+
+// ShipBullet is a player bullet in a delta, tagged with the slot that fired it
+type ShipBullet struct {
+	Ship int `json:"ship"`
+	GameObject
+}
+
+// KeyFrame wraps a full snapshot
+func KeyFrame(g *Game) Frame {
+	return Frame{Type: FrameState, Seq: g.Tick, Game: g}
+}
+
+// DeltaFrame returns the change from base to next. ok is false when next
+// cannot be expressed as a delta on base, such as after a level change
+// replaced the whole wave; send a keyframe then.
+func DeltaFrame(base, next *Game) (Frame, bool) {
+	if base.ID != next.ID || base.Level != next.Level || base.Tick > next.Tick {
+		return Frame{}, false
+	}
+
+	delta := &Delta{
+		BaseSeq:   base.Tick,
+		Seq:       next.Tick,
+		Status:    next.Status,
+		Score:     next.Score,
+		Level:     next.Level,
+		Lives:     next.Lives,
+		Formation: next.Formation,
+		Boss:      next.Boss,
+		Flags:     next.Flags,
+	}
+
+	if !seatsEqual(base.Seats, next.Seats) {
+		delta.Seats = next.Seats
+	}
+	for _, ship := range next.Ships {
+		if ship.Slot >= len(base.Ships) || !shipsEqual(base.Ships[ship.Slot], ship) {
+			ship.Bullets = nil
+			delta.Ships = append(delta.Ships, ship)
+		}
+	}
+
+	// Destroyed enemies stay in the wave inactive; clients only see them go
+	previous := make(map[string]GameObject, len(base.Enemies))
+	for _, enemy := range base.Enemies {
+		if enemy.Active {
+			previous[enemy.ID] = enemy
+		}
+	}
+	for _, enemy := range next.Enemies {
+		old, existed := previous[enemy.ID]
+		delete(previous, enemy.ID)
+		switch {
+		case !enemy.Active && existed:
+			delta.Removed = append(delta.Removed, enemy.ID)
+		case enemy.Active && (!existed || old != enemy):
+			delta.Enemies = append(delta.Enemies, enemy)
+		}
+	}
+	for _, enemy := range base.Enemies {
+		if _, gone := previous[enemy.ID]; gone {
+			delta.Removed = append(delta.Removed, enemy.ID)
+		}
+	}
+
+	// Bullets leave their slices when they hit or fly off the field
+	fired := make(map[string]GameObject)
+	for _, ship := range base.Ships {
+		for _, bullet := range ship.Bullets {
+			fired[bullet.ID] = bullet
+		}
+	}
+	for _, ship := range next.Ships {
+		for _, bullet := range ship.Bullets {
+			if old, existed := fired[bullet.ID]; !existed || old != bullet {
+				delta.Bullets = append(delta.Bullets, ShipBullet{Ship: ship.Slot, GameObject: bullet})
+			}
+			delete(fired, bullet.ID)
+		}
+	}
+	for _, ship := range base.Ships {
+		for _, bullet := range ship.Bullets {
+			if _, gone := fired[bullet.ID]; gone {
+				delta.Removed = append(delta.Removed, bullet.ID)
+			}
+		}
+	}
+
+	fired = make(map[string]GameObject, len(base.EnemyBullets))
+	for _, bullet := range base.EnemyBullets {
+		fired[bullet.ID] = bullet
+	}
+	for _, bullet := range next.EnemyBullets {
+		if old, existed := fired[bullet.ID]; !existed || old != bullet {
+			delta.EnemyBullets = append(delta.EnemyBullets, bullet)
+		}
+		delete(fired, bullet.ID)
+	}
+	for _, bullet := range base.EnemyBullets {
+		if _, gone := fired[bullet.ID]; gone {
+			delta.Removed = append(delta.Removed, bullet.ID)
+		}
+	}
+
+	// Items leave theirs when caught or when they fall off the field
+	dropped := make(map[string]PowerUp, len(base.PowerUps))
+	for _, item := range base.PowerUps {
+		dropped[item.ID] = item
+	}
+	for _, item := range next.PowerUps {
+		if old, existed := dropped[item.ID]; !existed || old != item {
+			delta.PowerUps = append(delta.PowerUps, item)
+		}
+		delete(dropped, item.ID)
+	}
+	for _, item := range base.PowerUps {
+		if _, gone := dropped[item.ID]; gone {
+			delta.Removed = append(delta.Removed, item.ID)
+		}
+	}
+
+	standing := make(map[string]Bunker, len(base.Bunkers))
+	for _, bunker := range base.Bunkers {
+		standing[bunker.ID] = bunker
+	}
+	for _, bunker := range next.Bunkers {
+		if old, existed := standing[bunker.ID]; !existed || !bunkersEqual(old, bunker) {
+			delta.Bunkers = append(delta.Bunkers, bunker)
+		}
+		delete(standing, bunker.ID)
+	}
+	for _, bunker := range base.Bunkers {
+		if _, gone := standing[bunker.ID]; gone {
+			delta.Removed = append(delta.Removed, bunker.ID)
+		}
+	}
+
+	return Frame{Type: FrameDelta, Seq: next.Tick, Delta: delta}, true
+}
+
+// shipsEqual compares ships apart from their bullets
+func shipsEqual(a, b Ship) bool {
+	return a.GameObject == b.GameObject && a.Score == b.Score && a.FireCooldown == b.FireCooldown &&
+		a.InvulnerableTicks == b.InvulnerableTicks && slices.Equal(a.Effects, b.Effects)
+}
+
+func seatsEqual(a, b []Seat) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
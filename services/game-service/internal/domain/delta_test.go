@@ -0,0 +1,70 @@
+package domain
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestDeltaFrameNamesRemovedObjects(t *testing.T) {
+	g := NewGame("delta", 42, testLevels(t))
+	g.EnemyBullets = []GameObject{{ID: "enemy-bullet", Active: true}}
+	g.PowerUps = []PowerUp{{GameObject: GameObject{ID: "item", Active: true}, Kind: PowerUpSpread}}
+	base := g.Snapshot()
+
+	next := g.Snapshot()
+	next.Tick++
+	next.Enemies[0].Active = false
+	next.Enemies = next.Enemies[:len(next.Enemies)-1]
+	next.EnemyBullets = nil
+	next.PowerUps = nil
+	next.Bunkers = next.Bunkers[1:]
+
+	frame, ok := DeltaFrame(base, next)
+	if !ok {
+		t.Fatal("no delta between consecutive ticks")
+	}
+	if frame.Type != FrameDelta || frame.Delta.BaseSeq != base.Tick || frame.Seq != next.Tick {
+		t.Fatalf("frame = %s from %d to %d, want a delta from %d to %d", frame.Type, frame.Delta.BaseSeq, frame.Seq, base.Tick, next.Tick)
+	}
+
+	// Destroyed and dropped enemies, spent bullets, caught items and flattened
+	// bunkers all leave by id
+	want := []string{
+		base.Enemies[0].ID,
+		base.Enemies[len(base.Enemies)-1].ID,
+		"enemy-bullet",
+		"item",
+		base.Bunkers[0].ID,
+	}
+	removed := slices.Clone(frame.Delta.Removed)
+	slices.Sort(removed)
+	slices.Sort(want)
+	if !slices.Equal(removed, want) {
+		t.Errorf("removed = %v, want %v", removed, want)
+	}
+	if len(frame.Delta.Enemies) != 0 || len(frame.Delta.Bunkers) != 0 {
+		t.Errorf("delta resends %d enemies and %d bunkers that did not change", len(frame.Delta.Enemies), len(frame.Delta.Bunkers))
+	}
+}
+
+func TestDeltaFrameNeedsSameLevel(t *testing.T) {
+	g := NewGame("delta", 42, testLevels(t))
+
+	tests := []struct {
+		name   string
+		change func(base, next *Game)
+	}{
+		{"other game", func(base, next *Game) { next.ID = "other" }},
+		{"next level", func(base, next *Game) { next.Level++ }},
+		{"older tick", func(base, next *Game) { base.Tick, next.Tick = 5, 4 }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			base, next := g.Snapshot(), g.Snapshot()
+			tt.change(base, next)
+			if _, ok := DeltaFrame(base, next); ok {
+				t.Error("got a delta, want a keyframe")
+			}
+		})
+	}
+}
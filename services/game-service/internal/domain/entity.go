@@ -0,0 +1,37 @@
+package domain
+
+import "strconv"
+
+// Prefixes for the IDs of spawned objects
+const (
+	entityBoss        = "boss"
+	entityBullet      = "bullet"
+	entityBunker      = "bunker"
+	entityEnemy       = "enemy"
+	entityEnemyBullet = "enemy_bullet"
+	entityPowerUp     = "power_up"
+)
+
+// newEntityID allocates the next ID in the game. The counter lives on Game
+// and never resets, so an ID names one object for the whole game, across
+// levels, and replays allocate the same IDs in the same order.
+func (g *Game) newEntityID(kind string) string {
+	g.LastEntityID++
+	return kind + strconv.FormatUint(g.LastEntityID, 10)
+}
+
+// renumberEntities gives every object in a game saved before IDs were
+// allocated a fresh one, since those games named all bullets alike.
+func (g *Game) renumberEntities() {
+	for i := range g.Enemies {
+		g.Enemies[i].ID = g.newEntityID(entityEnemy)
+	}
+	for i := range g.Ships {
+		for j := range g.Ships[i].Bullets {
+			g.Ships[i].Bullets[j].ID = g.newEntityID(entityBullet)
+		}
+	}
+	for i := range g.EnemyBullets {
+		g.EnemyBullets[i].ID = g.newEntityID(entityEnemyBullet)
+	}
+}
@@ -0,0 +1,46 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Error is an error with a stable machine-readable code. Codes are part of
+// the public API, so a published code must never change; messages may.
+type Error struct {
+	Code    string
+	Message string
+}
+
+func NewError(code, message string) *Error {
+	return &Error{Code: code, Message: message}
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+// ErrorCode returns the code of the first *Error in err's chain, or "" when
+// there is none
+func ErrorCode(err error) string {
+	var coded *Error
+	if errors.As(err, &coded) {
+		return coded.Code
+	}
+	return ""
+}
+
+// SequenceError is ErrOutOfOrder with what a client needs to catch up: the
+// seq it sent and the last one acknowledged for its seat.
+type SequenceError struct {
+	Seq   uint64
+	Acked uint64
+}
+
+func (e *SequenceError) Error() string {
+	return fmt.Sprintf("%s: got seq %d, expected %d", ErrOutOfOrder.Message, e.Seq, e.Acked+1)
+}
+
+func (e *SequenceError) Unwrap() error {
+	return ErrOutOfOrder
+}
@@ -0,0 +1,122 @@
+package domain
+
+const (
+	PatternSweep   = "sweep"
+	PatternZigzag  = "zigzag"
+	PatternDescend = "descend"
+)
+
+const (
+	enemyWidth        = 25
+	formationStepDown = 20
+	// Extra horizontal speed reached once the last enemy of a wave is left
+	formationMaxBoost = 6
+)
+
+// Formation is the shared movement state of the enemy wave
+type Formation struct {
+	Pattern   string `json:"pattern"`
+	Direction int    `json:"direction"`
+}
+
+// MovementPattern advances the enemy wave by one tick
+type MovementPattern interface {
+	Move(g *Game)
+}
+
+var movementPatterns = map[string]MovementPattern{
+	PatternSweep:   sweepPattern{stepDown: formationStepDown},
+	PatternZigzag:  zigzagPattern{},
+	PatternDescend: descendPattern{},
+}
+
+func movementPattern(name string) MovementPattern {
+	if pattern, ok := movementPatterns[name]; ok {
+		return pattern
+	}
+	return movementPatterns[PatternSweep]
+}
+
+// sweepPattern is the classic invaders march: across the screen, down a row
+// at the edge, back again, faster as the wave thins out.
+type sweepPattern struct {
+	stepDown int
+}
+
+func (p sweepPattern) Move(g *Game) {
+	if g.Formation.Direction == 0 {
+		g.Formation.Direction = 1
+	}
+
+	dx := g.Formation.Direction * formationSpeed(g)
+	left, right, ok := activeBounds(g.Enemies)
+	if !ok {
+		return
+	}
+
+	if left+dx < 0 || right+enemyWidth+dx > g.Stage.World.Width {
+		g.Formation.Direction = -g.Formation.Direction
+		shiftEnemies(g.Enemies, 0, p.stepDown)
+		return
+	}
+	shiftEnemies(g.Enemies, dx, 0)
+}
+
+// zigzagPattern sweeps like the classic march but sinks continuously and
+// only nudges down at the edges, so the wave never pauses.
+type zigzagPattern struct{}
+
+func (zigzagPattern) Move(g *Game) {
+	sweepPattern{stepDown: formationStepDown / 4}.Move(g)
+	if g.Tick%3 == 0 {
+		shiftEnemies(g.Enemies, 0, 1)
+	}
+}
+
+// descendPattern is the original straight-down drift
+type descendPattern struct{}
+
+func (descendPattern) Move(g *Game) {
+	shiftEnemies(g.Enemies, 0, 1)
+}
+
+// formationSpeed grows linearly with the share of the wave already destroyed
+func formationSpeed(g *Game) int {
+	total := len(g.Enemies)
+	if total == 0 {
+		return g.Stage.EnemySpeed
+	}
+
+	destroyed := 0
+	for _, enemy := range g.Enemies {
+		if !enemy.Active {
+			destroyed++
+		}
+	}
+	return g.Stage.EnemySpeed + destroyed*formationMaxBoost/total
+}
+
+func activeBounds(enemies []GameObject) (left, right int, ok bool) {
+	for _, enemy := range enemies {
+		if !enemy.Active {
+			continue
+		}
+		if !ok || enemy.Position.X < left {
+			left = enemy.Position.X
+		}
+		if !ok || enemy.Position.X > right {
+			right = enemy.Position.X
+		}
+		ok = true
+	}
+	return left, right, ok
+}
+
+func shiftEnemies(enemies []GameObject, dx, dy int) {
+	for i := range enemies {
+		if enemies[i].Active {
+			enemies[i].Position.X += dx
+			enemies[i].Position.Y += dy
+		}
+	}
+}
@@ -0,0 +1,89 @@
+package domain
+
+import (
+	"slices"
+	"testing"
+)
+
+func enemiesAt(xs ...int) []GameObject {
+	enemies := make([]GameObject, len(xs))
+	for i, x := range xs {
+		enemies[i] = GameObject{Position: Position{X: x, Y: 100}, Active: true}
+	}
+	return enemies
+}
+
+func TestSweepPattern(t *testing.T) {
+	// The test world is 800 wide and the wave's base speed is 2
+	tests := []struct {
+		name      string
+		enemies   []GameObject
+		destroyed int
+		direction int
+		// wantX lists the enemies still standing
+		wantX         []int
+		wantY         int
+		wantDirection int
+	}{
+		{name: "marches right", enemies: enemiesAt(100, 200), direction: 1, wantX: []int{102, 202}, wantY: 100, wantDirection: 1},
+		{name: "marches left", enemies: enemiesAt(100, 200), direction: -1, wantX: []int{98, 198}, wantY: 100, wantDirection: -1},
+		{name: "starts to the right", enemies: enemiesAt(100, 200), wantX: []int{102, 202}, wantY: 100, wantDirection: 1},
+		{
+			name: "drops a row at the right edge", enemies: enemiesAt(100, 774), direction: 1,
+			wantX: []int{100, 774}, wantY: 100 + formationStepDown, wantDirection: -1,
+		},
+		{
+			name: "drops a row at the left edge", enemies: enemiesAt(1, 200), direction: -1,
+			wantX: []int{1, 200}, wantY: 100 + formationStepDown, wantDirection: 1,
+		},
+		{
+			// Half the wave gone adds half of formationMaxBoost: 2 + 3
+			name: "speeds up as the wave thins", enemies: enemiesAt(100, 200, 300, 400), destroyed: 2, direction: 1,
+			wantX: []int{105, 205}, wantY: 100, wantDirection: 1,
+		},
+		{
+			// The edge is judged by the enemies still standing
+			name: "destroyed enemy at the edge", enemies: enemiesAt(100, 790), destroyed: 1, direction: 1,
+			wantX: []int{105}, wantY: 100, wantDirection: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewGame("formation", 1, testLevels(t))
+			g.Enemies = tt.enemies
+			for i := 0; i < tt.destroyed; i++ {
+				g.Enemies[len(g.Enemies)-1-i].Active = false
+			}
+			g.Formation = Formation{Pattern: PatternSweep, Direction: tt.direction}
+
+			movementPattern(PatternSweep).Move(g)
+			var xs []int
+			for _, enemy := range g.Enemies {
+				if enemy.Active {
+					xs = append(xs, enemy.Position.X)
+				}
+			}
+			if !slices.Equal(xs, tt.wantX) {
+				t.Errorf("standing enemies at x %v, want %v", xs, tt.wantX)
+			}
+			if y := g.Enemies[0].Position.Y; y != tt.wantY {
+				t.Errorf("wave at y %d, want %d", y, tt.wantY)
+			}
+			if g.Formation.Direction != tt.wantDirection {
+				t.Errorf("direction = %d, want %d", g.Formation.Direction, tt.wantDirection)
+			}
+			for _, enemy := range g.Enemies[len(g.Enemies)-tt.destroyed:] {
+				if enemy.Position.Y != 100 {
+					t.Errorf("destroyed enemy moved to %v", enemy.Position)
+				}
+			}
+		})
+	}
+}
+
+func TestUnknownPatternSweeps(t *testing.T) {
+	if _, ok := movementPattern("spiral").(sweepPattern); !ok {
+		t.Error("unknown pattern does not fall back to the sweep")
+	}
+}
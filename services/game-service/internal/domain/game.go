@@ -1,15 +1,21 @@
 package domain
 
 import (
-	"errors"
 	"fmt"
 	"time"
 )
 
 var (
-	ErrGameNotFound = errors.New("game not found")
-	ErrGameOver     = errors.New("game is over")
-	ErrInvalidMove  = errors.New("invalid move")
+	ErrGameNotFound = NewError("game_not_found", "game not found")
+	ErrGameOver     = NewError("game_over", "game is over")
+	ErrGameActive   = NewError("game_active", "game is still in progress")
+	ErrInvalidMove  = NewError("invalid_move", "invalid move")
+	ErrInputBacklog = NewError("input_backlog", "too many pending inputs")
+	ErrFireCooldown = NewError("fire_cooldown", "weapon is cooling down")
+	ErrBulletLimit  = NewError("bullet_limit", "too many bullets in flight")
+	ErrGameFull     = NewError("game_full", "game is full")
+	ErrNotSeated    = NewError("not_seated", "player has not joined this game")
+	ErrOutOfOrder   = NewError("input_out_of_order", "input arrived out of order")
 )
 
 type GameStatus string
@@ -20,6 +26,23 @@ const (
 	StatusLost   GameStatus = "lost"
 )
 
+// Reasons a game can be flagged as suspicious and kept off rankings
+const (
+	FlagInputRate      = "input_rate"
+	FlagReplayMismatch = "replay_mismatch"
+)
+
+const (
+	startingLives        = 3
+	invulnerabilityTicks = 20
+	fireCooldownTicks    = 3
+	maxPlayerBullets     = 5
+	playerBulletSpeed    = 15
+	playerWidth          = 30
+	// Distance between the player's row and the bottom of the world
+	playerRowOffset = 50
+)
+
 type Position struct {
 	X int `json:"x"`
 	Y int `json:"y"`
@@ -31,53 +54,170 @@ type GameObject struct {
 	Active   bool     `json:"active"`
 }
 
+// Input is a player command queued until the next simulation tick. Ship is
+// the slot of the ship it controls.
+type Input struct {
+	Ship      int    `json:"ship,omitempty"`
+	Action    string `json:"action"`
+	Direction string `json:"direction,omitempty"`
+}
+
 type Game struct {
-	ID        string       `json:"id"`
-	Score     int          `json:"score"`
-	Level     int          `json:"level"`
-	Player    GameObject   `json:"player"`
-	Enemies   []GameObject `json:"enemies"`
-	Bullets   []GameObject `json:"bullets"`
-	Status    GameStatus   `json:"status"`
-	CreatedAt time.Time    `json:"created_at"`
-}
-
-func NewGame(id string) *Game {
-	return &Game{
-		ID:     id,
-		Score:  0,
-		Level:  1,
-		Player: GameObject{ID: "player", Position: Position{X: 385, Y: 550}, Active: true},
-		Enemies: []GameObject{
-			{ID: "enemy1", Position: Position{X: 100, Y: 50}, Active: true},
-			{ID: "enemy2", Position: Position{X: 200, Y: 50}, Active: true},
-			{ID: "enemy3", Position: Position{X: 300, Y: 50}, Active: true},
-			{ID: "enemy4", Position: Position{X: 400, Y: 50}, Active: true},
-			{ID: "enemy5", Position: Position{X: 500, Y: 50}, Active: true},
-			{ID: "enemy6", Position: Position{X: 150, Y: 100}, Active: true},
-			{ID: "enemy7", Position: Position{X: 250, Y: 100}, Active: true},
-			{ID: "enemy8", Position: Position{X: 350, Y: 100}, Active: true},
-			{ID: "enemy9", Position: Position{X: 450, Y: 100}, Active: true},
-		},
-		Bullets:   make([]GameObject, 0),
-		Status:    StatusActive,
-		CreatedAt: time.Now(),
-	}
-}
-
-func (g *Game) MovePlayer(direction string) error {
+	ID           string       `json:"id"`
+	PlayerID     string       `json:"player_id,omitempty"`
+	PlayerName   string       `json:"player_name"`
+	Seed         uint64       `json:"seed,omitempty"`
+	RNG          uint64       `json:"rng,omitempty"`
+	Tick         uint64       `json:"tick"`
+	Score        int          `json:"score"`
+	Level        int          `json:"level"`
+	Stage        *LevelDef    `json:"stage,omitempty"`
+	World        World        `json:"world"`
+	Lives        int          `json:"lives"`
+	Ships        []Ship       `json:"ships"`
+	Seats        []Seat       `json:"seats"`
+	Enemies      []GameObject `json:"enemies"`
+	Formation    Formation    `json:"formation"`
+	EnemyBullets []GameObject `json:"enemy_bullets"`
+	PowerUps     []PowerUp    `json:"power_ups"`
+	Bunkers      []Bunker     `json:"bunkers"`
+	Boss         *Boss        `json:"boss,omitempty"`
+	LastEntityID uint64       `json:"last_entity_id"`
+	Flags        []string     `json:"flags,omitempty"`
+	Status       GameStatus   `json:"status"`
+	CreatedAt    time.Time    `json:"created_at"`
+	LastInputAt  time.Time    `json:"last_input_at"`
+	EndedAt      *time.Time   `json:"ended_at,omitempty"`
+	// Inputs is the ordered log of applied inputs used for replays
+	Inputs []InputRecord `json:"inputs,omitempty"`
+	// You is only set on a View, never on a stored game
+	You *You `json:"you,omitempty"`
+
+	levels *LevelSet
+}
+
+// NewGame creates a game whose simulation is fully determined by seed and the
+// inputs applied to it. Slot 0's ship starts in play; other players join
+// through join inputs.
+func NewGame(id string, seed uint64, levels *LevelSet) *Game {
+	now := time.Now()
+	g := &Game{
+		ID:          id,
+		Seed:        seed,
+		RNG:         seed,
+		Score:       0,
+		Level:       1,
+		Ships:       []Ship{newShip(0)},
+		Lives:       startingLives,
+		Status:      StatusActive,
+		CreatedAt:   now,
+		LastInputAt: now,
+		levels:      levels,
+	}
+	g.Ships[0].Active = true
+	g.startLevel(levels.Level(1))
+	return g
+}
+
+// Restore prepares a game loaded from storage: it attaches the level set and
+// fills in state that records from older versions don't carry.
+func (g *Game) Restore(levels *LevelSet) {
+	g.levels = levels
+	if g.Stage == nil || g.Stage.Number == 0 {
+		def := levels.Level(g.Level)
+		g.Stage = &def
+	}
+	if g.World == (World{}) {
+		g.World = g.Stage.World
+	}
+	if len(g.Ships) == 0 {
+		ship := newShip(0)
+		ship.Active = true
+		g.spawnShip(&ship)
+		g.Ships = []Ship{ship}
+	}
+	if len(g.Seats) == 0 {
+		g.Seats = []Seat{{PlayerID: g.PlayerID, Name: g.PlayerName, JoinedAt: g.CreatedAt}}
+	}
+	if g.LastEntityID == 0 {
+		g.renumberEntities()
+	}
+	if g.PowerUps == nil {
+		g.PowerUps = make([]PowerUp, 0)
+	}
+	if g.Bunkers == nil {
+		g.Bunkers = make([]Bunker, 0)
+	}
+}
+
+// ValidateInput reports whether in could be applied to the game on its next tick.
+func (g *Game) ValidateInput(in Input) error {
+	if g.Status != StatusActive {
+		return ErrGameOver
+	}
+
+	if in.Ship < 0 || in.Ship >= MaxShips {
+		return fmt.Errorf("%w: ship %d does not exist", ErrInvalidMove, in.Ship)
+	}
+
+	switch in.Action {
+	case "move":
+		if in.Direction != "left" && in.Direction != "right" {
+			return fmt.Errorf("%w: unknown direction %q", ErrInvalidMove, in.Direction)
+		}
+	case "shoot", "update", "join", "leave":
+	default:
+		return fmt.Errorf("%w: unknown action %q", ErrInvalidMove, in.Action)
+	}
+
+	return nil
+}
+
+// ApplyInput executes a queued player command against the current state and
+// records it in the input log.
+func (g *Game) ApplyInput(in Input) error {
+	err := g.applyInput(in)
+	if err == nil && in.Action != "update" {
+		g.Inputs = append(g.Inputs, InputRecord{Tick: g.Tick, Ship: in.Ship, Action: in.Action, Direction: in.Direction})
+	}
+	return err
+}
+
+func (g *Game) applyInput(in Input) error {
+	switch in.Action {
+	case "move":
+		return g.MovePlayer(in.Ship, in.Direction)
+	case "shoot":
+		return g.Shoot(in.Ship)
+	case "join":
+		return g.joinShip(in.Ship)
+	case "leave":
+		return g.leaveShip(in.Ship)
+	case "update":
+		// Kept for older clients; the tick loop advances the game on its own
+		return nil
+	default:
+		return ErrInvalidMove
+	}
+}
+
+func (g *Game) MovePlayer(slot int, direction string) error {
 	if g.Status != StatusActive {
 		return ErrGameOver
 	}
+	ship, err := g.ship(slot)
+	if err != nil {
+		return err
+	}
 
 	switch direction {
 	case "left":
-		if g.Player.Position.X > 0 {
-			g.Player.Position.X -= 20
+		if ship.Position.X > 0 {
+			ship.Position.X -= 20
 		}
 	case "right":
-		if g.Player.Position.X < 780 {
-			g.Player.Position.X += 20
+		if ship.Position.X < g.Stage.World.Width-20 {
+			ship.Position.X += 20
 		}
 	default:
 		return ErrInvalidMove
@@ -86,76 +226,154 @@ func (g *Game) MovePlayer(direction string) error {
 	return nil
 }
 
-func (g *Game) Shoot() error {
+func (g *Game) Shoot(slot int) error {
 	if g.Status != StatusActive {
 		return ErrGameOver
 	}
+	ship, err := g.ship(slot)
+	if err != nil {
+		return err
+	}
+	if ship.FireCooldown > 0 {
+		return ErrFireCooldown
+	}
 
-	bullet := GameObject{
-		ID:       "bullet",
-		Position: Position{X: g.Player.Position.X, Y: g.Player.Position.Y - 10},
-		Active:   true,
+	// A spread shot is several bullets abreast, with the limit raised to match
+	shots := 1
+	if ship.hasEffect(PowerUpSpread) {
+		shots = spreadBullets
+	}
+	if len(ship.Bullets)+shots > maxPlayerBullets*shots {
+		return ErrBulletLimit
+	}
+
+	for i := 0; i < shots; i++ {
+		offset := (i - shots/2) * spreadGap
+		ship.Bullets = append(ship.Bullets, GameObject{
+			ID:       g.newEntityID(entityBullet),
+			Position: Position{X: ship.Position.X + offset, Y: ship.Position.Y - 10},
+			Active:   true,
+		})
+	}
+	ship.FireCooldown = fireCooldownTicks
+	if ship.hasEffect(PowerUpRapidFire) {
+		ship.FireCooldown = rapidFireCooldownTicks
 	}
-	g.Bullets = append(g.Bullets, bullet)
 
 	return nil
 }
 
+// Flag marks the game as suspicious. Each reason is recorded once.
+func (g *Game) Flag(reason string) bool {
+	for _, existing := range g.Flags {
+		if existing == reason {
+			return false
+		}
+	}
+	g.Flags = append(g.Flags, reason)
+	return true
+}
+
+func (g *Game) Flagged() bool {
+	return len(g.Flags) > 0
+}
+
 func (g *Game) Update() {
 	if g.Status != StatusActive {
 		return
 	}
+	g.Tick++
+
+	for i := range g.Ships {
+		ship := &g.Ships[i]
 
-	// Move bullets up
-	activeBullets := make([]GameObject, 0)
-	for i := range g.Bullets {
-		if g.Bullets[i].Active {
-			g.Bullets[i].Position.Y -= 15
-			if g.Bullets[i].Position.Y >= 0 {
-				activeBullets = append(activeBullets, g.Bullets[i])
+		// Move bullets up, stopping those that strike a bunker
+		activeBullets := make([]GameObject, 0)
+		for j := range ship.Bullets {
+			bullet := ship.Bullets[j]
+			if bullet.Active {
+				bullet.Position.Y -= playerBulletSpeed
+				if !g.bunkerHit(bullet.Position.X, bullet.Position.Y+playerBulletSpeed, bullet.Position.Y) && bullet.Position.Y >= 0 {
+					activeBullets = append(activeBullets, bullet)
+				}
 			}
 		}
+		ship.Bullets = activeBullets
+
+		if ship.InvulnerableTicks > 0 {
+			ship.InvulnerableTicks--
+		}
+		if ship.FireCooldown > 0 {
+			ship.FireCooldown--
+		}
+		g.expireEffects(ship)
 	}
-	g.Bullets = activeBullets
 
-	// Move enemies down slowly
-	for i := range g.Enemies {
-		if g.Enemies[i].Active {
-			g.Enemies[i].Position.Y += 1
-			// Check if enemies reached bottom
-			if g.Enemies[i].Position.Y > 550 {
-				g.Status = StatusLost
-				return
-			}
+	// Move the enemy wave as a formation
+	movementPattern(g.Formation.Pattern).Move(g)
+	g.moveBoss()
+	g.enemiesCrushBunkers()
+
+	// Check if enemies reached bottom
+	landed := false
+	for _, enemy := range g.Enemies {
+		if enemy.Active && enemy.Position.Y > g.playerRow() {
+			landed = true
+			break
+		}
+	}
+	if landed {
+		// An invasion always costs a life and pushes the wave back to the top
+		g.loseLife(nil)
+		if g.Status != StatusActive {
+			return
 		}
+		g.resetWave()
 	}
 
-	// Check collisions
-	for i := range g.Bullets {
-		for j := range g.Enemies {
-			if !g.Enemies[j].Active {
-				continue
+	g.enemiesFire()
+	g.bossFire()
+	g.moveEnemyBullets()
+	if g.Status != StatusActive {
+		return
+	}
+	g.movePowerUps()
+
+	// Check collisions, crediting each kill to the ship that fired
+	for i := range g.Ships {
+		ship := &g.Ships[i]
+		for j := range ship.Bullets {
+			for k := range g.Enemies {
+				if !g.Enemies[k].Active {
+					continue
+				}
+				if g.checkCollision(ship.Bullets[j], g.Enemies[k]) {
+					ship.Bullets[j].Active = false
+					g.Enemies[k].Active = false
+					ship.Score += 10
+					g.Score += 10
+					g.dropPowerUp(g.Enemies[k].Position)
+					break
+				}
 			}
-			if g.checkCollision(g.Bullets[i], g.Enemies[j]) {
-				g.Bullets[i].Active = false
-				g.Enemies[j].Active = false
-				g.Score += 10
-				break
+			if ship.Bullets[j].Active && g.bossHitBy(ship.Bullets[j]) {
+				ship.Bullets[j].Active = false
+				g.damageBoss(ship)
 			}
 		}
-	}
 
-	// Remove inactive bullets
-	activeBullets = make([]GameObject, 0)
-	for _, bullet := range g.Bullets {
-		if bullet.Active {
-			activeBullets = append(activeBullets, bullet)
+		// Remove inactive bullets
+		activeBullets := make([]GameObject, 0)
+		for _, bullet := range ship.Bullets {
+			if bullet.Active {
+				activeBullets = append(activeBullets, bullet)
+			}
 		}
+		ship.Bullets = activeBullets
 	}
-	g.Bullets = activeBullets
 
-	// Check win condition
-	allEnemiesDestroyed := true
+	// Check win condition; a boss level is cleared once the boss is defeated
+	allEnemiesDestroyed := g.Boss == nil
 	for _, enemy := range g.Enemies {
 		if enemy.Active {
 			allEnemiesDestroyed = false
@@ -167,33 +385,198 @@ func (g *Game) Update() {
 	}
 }
 
+// enemiesFire lets a random enemy shoot every FireInterval ticks
+func (g *Game) enemiesFire() {
+	interval := uint64(g.Stage.FireInterval)
+	if g.Tick%interval != 0 {
+		return
+	}
+
+	shooters := make([]int, 0, len(g.Enemies))
+	for i := range g.Enemies {
+		if g.Enemies[i].Active {
+			shooters = append(shooters, i)
+		}
+	}
+	if len(shooters) == 0 {
+		return
+	}
+
+	shooter := g.Enemies[shooters[g.randomIntn(len(shooters))]]
+	g.EnemyBullets = append(g.EnemyBullets, GameObject{
+		ID:       g.newEntityID(entityEnemyBullet),
+		Position: Position{X: shooter.Position.X + 10, Y: shooter.Position.Y + 20},
+		Active:   true,
+	})
+}
+
+func (g *Game) moveEnemyBullets() {
+	activeBullets := make([]GameObject, 0, len(g.EnemyBullets))
+	for _, bullet := range g.EnemyBullets {
+		bullet.Position.Y += g.Stage.BulletSpeed
+		if g.bunkerHit(bullet.Position.X, bullet.Position.Y-g.Stage.BulletSpeed, bullet.Position.Y) {
+			continue
+		}
+		if bullet.Position.Y > g.Stage.World.Height {
+			continue
+		}
+		if ship := g.shipHitBy(bullet); ship != nil {
+			// A shield absorbs the bullet
+			if ship.InvulnerableTicks == 0 && !ship.hasEffect(PowerUpShield) {
+				g.loseLife(ship)
+			}
+			continue
+		}
+		activeBullets = append(activeBullets, bullet)
+	}
+	g.EnemyBullets = activeBullets
+}
+
+func (g *Game) shipHitBy(bullet GameObject) *Ship {
+	for i := range g.Ships {
+		if g.Ships[i].Active && g.checkCollision(bullet, g.Ships[i].GameObject) {
+			return &g.Ships[i]
+		}
+	}
+	return nil
+}
+
+// loseLife takes a life from the shared pool. The ship that was hit, or every
+// ship when ship is nil, is briefly invulnerable afterwards.
+func (g *Game) loseLife(ship *Ship) {
+	g.Lives--
+	if g.Lives <= 0 {
+		g.Lives = 0
+		g.Status = StatusLost
+		return
+	}
+	if ship != nil {
+		ship.InvulnerableTicks = invulnerabilityTicks
+		return
+	}
+	for i := range g.Ships {
+		g.Ships[i].InvulnerableTicks = invulnerabilityTicks
+	}
+}
+
+// resetWave moves the surviving enemies back up so the topmost row starts over
+func (g *Game) resetWave() {
+	top := -1
+	for _, enemy := range g.Enemies {
+		if enemy.Active && (top < 0 || enemy.Position.Y < top) {
+			top = enemy.Position.Y
+		}
+	}
+	if top < 0 {
+		return
+	}
+
+	spawnTop := top
+	for _, pos := range g.Stage.EnemyPositions() {
+		if pos.Y < spawnTop {
+			spawnTop = pos.Y
+		}
+	}
+	for i := range g.Enemies {
+		g.Enemies[i].Position.Y -= top - spawnTop
+	}
+	g.EnemyBullets = make([]GameObject, 0)
+}
+
+// Snapshot returns a deep copy that is safe to serialize while the
+// simulation keeps mutating the original. The seed, random state and input
+// log are left out, since they would let a client predict the game; they are
+// only served through Replay. So is the level definition, of which clients
+// only need the World. Player ids are left out too: snapshots reach
+// spectators, and a player id is all it takes to move someone's ship.
+func (g *Game) Snapshot() *Game {
+	snapshot := g.Clone()
+	snapshot.PlayerID = ""
+	snapshot.Seed = 0
+	snapshot.RNG = 0
+	snapshot.Stage = nil
+	snapshot.Inputs = nil
+	for i := range snapshot.Seats {
+		snapshot.Seats[i].PlayerID = ""
+	}
+	return snapshot
+}
+
+// View is the snapshot sent to playerID, with You set when they hold a seat
+func (g *Game) View(playerID string) *Game {
+	view := g.Snapshot()
+	view.You = g.YouOf(playerID)
+	return view
+}
+
+// Clone returns a deep copy of the full game state, input log included
+func (g *Game) Clone() *Game {
+	// Stage is replaced on every level rather than changed, so it is shared
+	clone := *g
+	clone.Ships = cloneShips(g.Ships)
+	clone.Seats = append([]Seat(nil), g.Seats...)
+	clone.Enemies = cloneObjects(g.Enemies)
+	clone.EnemyBullets = cloneObjects(g.EnemyBullets)
+	clone.PowerUps = clonePowerUps(g.PowerUps)
+	clone.Bunkers = cloneBunkers(g.Bunkers)
+	clone.Boss = cloneBoss(g.Boss)
+	clone.Inputs = append([]InputRecord(nil), g.Inputs...)
+	clone.Flags = append([]string(nil), g.Flags...)
+	return &clone
+}
+
+func cloneObjects(objects []GameObject) []GameObject {
+	clone := make([]GameObject, len(objects))
+	copy(clone, objects)
+	return clone
+}
+
 func (g *Game) checkCollision(obj1, obj2 GameObject) bool {
 	return abs(obj1.Position.X-obj2.Position.X) < 30 && abs(obj1.Position.Y-obj2.Position.Y) < 30
 }
 
 func (g *Game) nextLevel() {
+	g.Score += g.Stage.Bonus // Level bonus
 	g.Level++
-	g.Score += 50 // Level bonus
-	
-	// Reset player position
-	g.Player.Position = Position{X: 385, Y: 550}
-	
-	// Create new enemies with increased difficulty
-	enemyCount := 9 + g.Level // More enemies each level
+	g.startLevel(g.levels.Level(g.Level))
+}
+
+// startLevel spawns the wave described by def and resets the playfield
+func (g *Game) startLevel(def LevelDef) {
+	g.Stage = &def
+	g.World = def.World
+
+	// Reset ship positions and clear their bullets
+	for i := range g.Ships {
+		g.spawnShip(&g.Ships[i])
+		g.Ships[i].Bullets = make([]GameObject, 0)
+	}
+
+	// A boss takes the place of the level's wave
 	g.Enemies = make([]GameObject, 0)
-	
-	for i := 0; i < enemyCount; i++ {
-		row := i / 5
-		col := i % 5
-		g.Enemies = append(g.Enemies, GameObject{
-			ID:       fmt.Sprintf("enemy%d", i+1),
-			Position: Position{X: 100 + col*100, Y: 50 + row*50},
-			Active:   true,
-		})
+	g.Boss = nil
+	if def.Boss != nil {
+		g.spawnBoss()
+	} else {
+		for _, pos := range def.EnemyPositions() {
+			g.Enemies = append(g.Enemies, GameObject{
+				ID:       g.newEntityID(entityEnemy),
+				Position: pos,
+				Active:   true,
+			})
+		}
 	}
-	
-	// Clear bullets
-	g.Bullets = make([]GameObject, 0)
+
+	g.Formation = Formation{Pattern: def.Pattern, Direction: 1}
+	g.placeBunkers(def.Bunkers)
+
+	// Clear enemy bullets and items nobody caught
+	g.EnemyBullets = make([]GameObject, 0)
+	g.PowerUps = make([]PowerUp, 0)
+}
+
+func (g *Game) playerRow() int {
+	return g.Stage.World.Height - playerRowOffset
 }
 
 func abs(x int) int {
@@ -201,4 +584,4 @@ func abs(x int) int {
 		return -x
 	}
 	return x
-}
\ No newline at end of file
+}
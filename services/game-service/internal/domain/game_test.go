@@ -0,0 +1,72 @@
+package domain
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+)
+
+func TestSnapshotLeavesOutStoredState(t *testing.T) {
+	g := NewGame("snapshot", 42, testLevels(t))
+	g.PlayerID = "owner"
+	g.Seats = []Seat{{Slot: 0, PlayerID: "owner"}, {Slot: 1, PlayerID: "guest"}}
+	play(g, 50)
+
+	data, err := json.Marshal(g.Snapshot())
+	if err != nil {
+		t.Fatalf("encoding snapshot: %v", err)
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		t.Fatalf("decoding snapshot: %v", err)
+	}
+	for _, hidden := range []string{"player_id", "seed", "rng", "stage", "inputs", "you"} {
+		if _, ok := fields[hidden]; ok {
+			t.Errorf("snapshot carries %q", hidden)
+		}
+	}
+	if bytes.Contains(data, []byte("owner")) || bytes.Contains(data, []byte("guest")) {
+		t.Error("snapshot carries a seat's player id")
+	}
+	if _, ok := fields["world"]; !ok {
+		t.Error("snapshot is missing the world")
+	}
+
+	// The stored form keeps everything needed to carry on and to replay
+	stored := g.Clone()
+	if stored.Seed != 42 || stored.RNG != g.RNG || stored.Stage == nil || len(stored.Inputs) != len(g.Inputs) {
+		t.Error("clone lost state that storage needs")
+	}
+}
+
+func TestViewSaysWhichSeatIsYours(t *testing.T) {
+	g := NewGame("view", 1, testLevels(t))
+	g.Seats = []Seat{
+		{Slot: 0, PlayerID: "owner", AckedSeq: 3},
+		{Slot: 1, PlayerID: "guest", AckedSeq: 7},
+		{Slot: 2, PlayerID: "gone", Left: true},
+	}
+
+	tests := []struct {
+		player string
+		want   *You
+	}{
+		{"guest", &You{Slot: 1, AckedSeq: 7}},
+		{"owner", &You{Slot: 0, AckedSeq: 3}},
+		{"gone", nil},
+		{"stranger", nil},
+		{"", nil},
+	}
+	for _, tt := range tests {
+		view := g.View(tt.player)
+		if (view.You == nil) != (tt.want == nil) || (view.You != nil && *view.You != *tt.want) {
+			t.Errorf("view for %q: you = %+v, want %+v", tt.player, view.You, tt.want)
+		}
+		if view.Seats[1].PlayerID != "" {
+			t.Errorf("view for %q carries player ids", tt.player)
+		}
+	}
+	if g.You != nil || g.Seats[1].PlayerID != "guest" {
+		t.Error("taking a view changed the game")
+	}
+}
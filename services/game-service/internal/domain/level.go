@@ -0,0 +1,213 @@
+package domain
+
+import (
+	"fmt"
+	"sort"
+)
+
+const (
+	minWorldWidth  = 200
+	minWorldHeight = 200
+	// Rows kept clear above the player so a wave never spawns already landed
+	playerZoneHeight = 150
+)
+
+type World struct {
+	Width  int `json:"width" yaml:"width"`
+	Height int `json:"height" yaml:"height"`
+}
+
+// GridLayout places Count enemies row by row, Columns per row
+type GridLayout struct {
+	Count    int      `json:"count" yaml:"count"`
+	Columns  int      `json:"columns" yaml:"columns"`
+	Origin   Position `json:"origin" yaml:"origin"`
+	SpacingX int      `json:"spacing_x" yaml:"spacing_x"`
+	SpacingY int      `json:"spacing_y" yaml:"spacing_y"`
+}
+
+// LevelDef describes one wave. Exactly one of Positions or Grid lays out the enemies.
+type LevelDef struct {
+	Number       int    `json:"number" yaml:"number"`
+	World        World  `json:"world" yaml:"world"`
+	Pattern      string `json:"pattern" yaml:"pattern"`
+	EnemySpeed   int    `json:"enemy_speed" yaml:"enemy_speed"`
+	FireInterval int    `json:"fire_interval" yaml:"fire_interval"`
+	BulletSpeed  int    `json:"bullet_speed" yaml:"bullet_speed"`
+	Bonus        int    `json:"bonus" yaml:"bonus"`
+	// PowerUps is left zero for levels whose enemies drop nothing
+	PowerUps  PowerUpDrops `json:"power_ups" yaml:"power_ups"`
+	Positions []Position   `json:"positions,omitempty" yaml:"positions,omitempty"`
+	Grid      *GridLayout  `json:"grid,omitempty" yaml:"grid,omitempty"`
+	// Bunkers is nil for levels without cover
+	Bunkers *BunkerLayout `json:"bunkers,omitempty" yaml:"bunkers,omitempty"`
+	// Boss is set by the level set on boss levels rather than in level files
+	Boss *BossDef `json:"boss,omitempty" yaml:"-"`
+}
+
+// EnemyPositions expands the layout into spawn points
+func (d LevelDef) EnemyPositions() []Position {
+	if d.Grid == nil {
+		return append([]Position(nil), d.Positions...)
+	}
+
+	positions := make([]Position, 0, d.Grid.Count)
+	for i := 0; i < d.Grid.Count; i++ {
+		row := i / d.Grid.Columns
+		col := i % d.Grid.Columns
+		positions = append(positions, Position{
+			X: d.Grid.Origin.X + col*d.Grid.SpacingX,
+			Y: d.Grid.Origin.Y + row*d.Grid.SpacingY,
+		})
+	}
+	return positions
+}
+
+// Validate returns every problem found in the definition, not just the first
+func (d LevelDef) Validate() []error {
+	var errs []error
+	fail := func(field, format string, args ...interface{}) {
+		errs = append(errs, fmt.Errorf("%s: %s", field, fmt.Sprintf(format, args...)))
+	}
+
+	if d.Number < 1 {
+		fail("number", "must be at least 1, got %d", d.Number)
+	}
+	if d.World.Width < minWorldWidth {
+		fail("world.width", "must be at least %d, got %d", minWorldWidth, d.World.Width)
+	}
+	if d.World.Height < minWorldHeight {
+		fail("world.height", "must be at least %d, got %d", minWorldHeight, d.World.Height)
+	}
+	if _, ok := movementPatterns[d.Pattern]; !ok {
+		fail("pattern", "unknown movement pattern %q", d.Pattern)
+	}
+	if d.EnemySpeed < 0 {
+		fail("enemy_speed", "must not be negative, got %d", d.EnemySpeed)
+	}
+	if d.FireInterval < 1 {
+		fail("fire_interval", "must be at least 1 tick, got %d", d.FireInterval)
+	}
+	if d.BulletSpeed < 1 {
+		fail("bullet_speed", "must be at least 1, got %d", d.BulletSpeed)
+	}
+	if d.Bonus < 0 {
+		fail("bonus", "must not be negative, got %d", d.Bonus)
+	}
+	if d.PowerUps.DropChance < 0 || d.PowerUps.DropChance > 100 {
+		fail("power_ups.drop_chance", "must be a percentage from 0 to 100, got %d", d.PowerUps.DropChance)
+	}
+	for _, option := range d.PowerUps.Weights.options() {
+		if option.weight < 0 {
+			fail("power_ups.weights."+option.kind, "must not be negative, got %d", option.weight)
+		}
+	}
+	if d.PowerUps.DropChance > 0 && d.PowerUps.Weights.total() <= 0 {
+		fail("power_ups.weights", "at least one kind needs a positive weight when drop_chance is set")
+	}
+	if b := d.Bunkers; b != nil {
+		if b.Count < 1 {
+			fail("bunkers.count", "must be at least 1, got %d", b.Count)
+		}
+		if b.Columns < 1 || b.Rows < 1 || b.CellSize < 1 {
+			fail("bunkers", "columns, rows and cell_size must be positive")
+		} else if b.Count >= 1 && b.Count*b.Columns*b.CellSize > d.World.Width {
+			fail("bunkers", "%d bunkers %d px wide do not fit a world %d px wide", b.Count, b.Columns*b.CellSize, d.World.Width)
+		}
+		if bottom := b.Y + b.Rows*b.CellSize; b.Y < 0 || bottom > d.World.Height-playerRowOffset {
+			fail("bunkers.y", "bunkers must sit above the player row at %d, got %d-%d", d.World.Height-playerRowOffset, b.Y, bottom)
+		}
+	}
+
+	switch {
+	case d.Grid != nil && len(d.Positions) > 0:
+		fail("grid", "cannot be combined with positions")
+		return errs
+	case d.Grid == nil && len(d.Positions) == 0:
+		fail("positions", "a level needs either positions or a grid")
+		return errs
+	case d.Grid != nil:
+		if d.Grid.Count < 1 {
+			fail("grid.count", "must be at least 1, got %d", d.Grid.Count)
+		}
+		if d.Grid.Columns < 1 {
+			fail("grid.columns", "must be at least 1, got %d", d.Grid.Columns)
+		}
+		if d.Grid.SpacingX < 1 || d.Grid.SpacingY < 1 {
+			fail("grid.spacing", "spacing_x and spacing_y must be positive")
+		}
+		if d.Grid.Count < 1 || d.Grid.Columns < 1 {
+			return errs
+		}
+	}
+
+	for i, pos := range d.EnemyPositions() {
+		if pos.X < 0 || pos.X > d.World.Width-enemyWidth || pos.Y < 0 || pos.Y > d.World.Height-playerZoneHeight {
+			fail(fmt.Sprintf("enemy[%d]", i), "position (%d,%d) is outside the playable area", pos.X, pos.Y)
+		}
+	}
+	return errs
+}
+
+// LevelSet is the ordered list of levels a game progresses through. Levels
+// past the last definition repeat it, a grid wave with one extra enemy per
+// level. With a boss, every boss.Every-th level is fought against the boss
+// instead.
+type LevelSet struct {
+	levels []LevelDef
+	boss   *BossDef
+}
+
+// NewLevelSet orders defs by number and checks that they run 1..n without
+// gaps. boss may be nil for a set without boss levels.
+func NewLevelSet(defs []LevelDef, boss *BossDef) (*LevelSet, error) {
+	if len(defs) == 0 {
+		return nil, fmt.Errorf("no levels defined")
+	}
+
+	sorted := append([]LevelDef(nil), defs...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Number < sorted[j].Number })
+	for i, def := range sorted {
+		if def.Number != i+1 {
+			return nil, fmt.Errorf("levels must be numbered 1..%d without gaps or duplicates, found %d at position %d", len(sorted), def.Number, i+1)
+		}
+	}
+	return &LevelSet{levels: sorted, boss: boss}, nil
+}
+
+func (s *LevelSet) Len() int {
+	return len(s.levels)
+}
+
+// Level returns the definition for the given 1-based level number
+func (s *LevelSet) Level(number int) LevelDef {
+	def := s.extend(number)
+	if s.boss != nil && number%s.boss.Every == 0 {
+		boss := *s.boss
+		def.Boss = &boss
+	}
+	return def
+}
+
+// extend returns the definition of number, growing the last one for levels
+// past the end of the set
+func (s *LevelSet) extend(number int) LevelDef {
+	if number <= len(s.levels) {
+		return s.levels[number-1]
+	}
+
+	def := s.levels[len(s.levels)-1]
+	extra := number - def.Number
+	def.Number = number
+	if def.Grid != nil {
+		grid := *def.Grid
+		grid.Count += extra
+		// Stop growing once the wave would spill into the player zone
+		rows := (def.World.Height-playerZoneHeight-grid.Origin.Y)/grid.SpacingY + 1
+		if grid.Count > rows*grid.Columns {
+			grid.Count = rows * grid.Columns
+		}
+		def.Grid = &grid
+	}
+	return def
+}
@@ -0,0 +1,44 @@
+package domain
+
+import (
+	"strings"
+	"unicode"
+)
+
+const (
+	maxPlayerNameRunes = 20
+	maxPlayerIDLength  = 64
+)
+
+// NormalizePlayerName trims the nickname and falls back to "anonymous". Names
+// are limited to 20 letters, digits, spaces, dashes and underscores.
+func NormalizePlayerName(name string) (string, bool) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return "anonymous", true
+	}
+	if len([]rune(name)) > maxPlayerNameRunes {
+		return "", false
+	}
+	for _, r := range name {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != ' ' && r != '-' && r != '_' {
+			return "", false
+		}
+	}
+	return name, true
+}
+
+// ValidPlayerID reports whether id can identify a player. An empty id is an
+// anonymous player; ids are opaque but limited to 64 letters, digits, dashes
+// and underscores.
+func ValidPlayerID(id string) bool {
+	if len(id) > maxPlayerIDLength {
+		return false
+	}
+	for _, c := range id {
+		if !(c >= 'a' && c <= 'z') && !(c >= 'A' && c <= 'Z') && !(c >= '0' && c <= '9') && c != '-' && c != '_' {
+			return false
+		}
+	}
+	return true
+}
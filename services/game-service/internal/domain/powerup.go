@@ -0,0 +1,159 @@
+package domain
+
+// Power-up kinds. An extra life is used up on pickup; the others are timed
+// effects on the ship that caught them.
+const (
+	PowerUpSpread    = "spread"
+	PowerUpRapidFire = "rapid_fire"
+	PowerUpShield    = "shield"
+	PowerUpExtraLife = "extra_life"
+)
+
+const (
+	// 15 seconds at the default tick rate
+	effectTicks      = 150
+	powerUpFallSpeed = 4
+	// Rapid fire lets a ship shoot on every tick
+	rapidFireCooldownTicks = 1
+	// A spread shot fires this many bullets abreast, spreadGap pixels apart
+	spreadBullets = 3
+	spreadGap     = 20
+	maxLives      = 9
+)
+
+// PowerUpDrops configures the items a level's enemies drop. DropChance is the
+// percentage of destroyed enemies that drop one, and the weights decide which
+// kind it is.
+type PowerUpDrops struct {
+	DropChance int            `json:"drop_chance" yaml:"drop_chance"`
+	Weights    PowerUpWeights `json:"weights" yaml:"weights"`
+}
+
+// PowerUpWeights are relative odds; a kind with weight 0 never drops
+type PowerUpWeights struct {
+	Spread    int `json:"spread" yaml:"spread"`
+	RapidFire int `json:"rapid_fire" yaml:"rapid_fire"`
+	Shield    int `json:"shield" yaml:"shield"`
+	ExtraLife int `json:"extra_life" yaml:"extra_life"`
+}
+
+type weightedKind struct {
+	kind   string
+	weight int
+}
+
+// options lists the kinds in a fixed order, so a roll picks the same kind
+// when the game is replayed
+func (w PowerUpWeights) options() []weightedKind {
+	return []weightedKind{
+		{PowerUpSpread, w.Spread},
+		{PowerUpRapidFire, w.RapidFire},
+		{PowerUpShield, w.Shield},
+		{PowerUpExtraLife, w.ExtraLife},
+	}
+}
+
+func (w PowerUpWeights) total() int {
+	return w.Spread + w.RapidFire + w.Shield + w.ExtraLife
+}
+
+// PowerUp is a dropped item falling toward the ships
+type PowerUp struct {
+	GameObject
+	Kind string `json:"kind"`
+}
+
+// Effect is a timed power-up on a ship. It wears off once the game reaches
+// tick ExpiresAt.
+type Effect struct {
+	Kind      string `json:"kind"`
+	ExpiresAt uint64 `json:"expires_at"`
+}
+
+// dropPowerUp rolls for an item where an enemy was destroyed. Levels without
+// drops leave the random sequence untouched.
+func (g *Game) dropPowerUp(at Position) {
+	drops := g.Stage.PowerUps
+	total := drops.Weights.total()
+	if drops.DropChance <= 0 || total <= 0 {
+		return
+	}
+	if g.randomIntn(100) >= drops.DropChance {
+		return
+	}
+
+	roll := g.randomIntn(total)
+	for _, option := range drops.Weights.options() {
+		if roll < option.weight {
+			g.PowerUps = append(g.PowerUps, PowerUp{
+				GameObject: GameObject{ID: g.newEntityID(entityPowerUp), Position: at, Active: true},
+				Kind:       option.kind,
+			})
+			return
+		}
+		roll -= option.weight
+	}
+}
+
+// movePowerUps lets items fall and gives each one to the first ship it touches
+func (g *Game) movePowerUps() {
+	falling := make([]PowerUp, 0, len(g.PowerUps))
+	for _, item := range g.PowerUps {
+		item.Position.Y += powerUpFallSpeed
+		if item.Position.Y > g.Stage.World.Height {
+			continue
+		}
+		if ship := g.shipHitBy(item.GameObject); ship != nil {
+			g.collect(ship, item.Kind)
+			continue
+		}
+		falling = append(falling, item)
+	}
+	g.PowerUps = falling
+}
+
+// collect applies a caught item. Catching an effect the ship already has
+// restarts its timer.
+func (g *Game) collect(ship *Ship, kind string) {
+	if kind == PowerUpExtraLife {
+		if g.Lives < maxLives {
+			g.Lives++
+		}
+		return
+	}
+
+	expiresAt := g.Tick + effectTicks
+	for i := range ship.Effects {
+		if ship.Effects[i].Kind == kind {
+			ship.Effects[i].ExpiresAt = expiresAt
+			return
+		}
+	}
+	ship.Effects = append(ship.Effects, Effect{Kind: kind, ExpiresAt: expiresAt})
+}
+
+// expireEffects drops the effects that have run out
+func (g *Game) expireEffects(ship *Ship) {
+	var active []Effect
+	for _, effect := range ship.Effects {
+		if effect.ExpiresAt > g.Tick {
+			active = append(active, effect)
+		}
+	}
+	ship.Effects = active
+}
+
+func (s *Ship) hasEffect(kind string) bool {
+	for _, effect := range s.Effects {
+		if effect.Kind == kind {
+			return true
+		}
+	}
+	return false
+}
+
+func clonePowerUps(items []PowerUp) []PowerUp {
+	clone := make([]PowerUp, len(items))
+	copy(clone, items)
+	return clone
+}
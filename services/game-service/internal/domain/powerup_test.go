@@ -0,0 +1,145 @@
+package domain
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestDropPowerUp(t *testing.T) {
+	at := Position{X: 200, Y: 100}
+	tests := []struct {
+		name     string
+		drops    PowerUpDrops
+		wantKind string
+	}{
+		{name: "no chance", drops: PowerUpDrops{DropChance: 0, Weights: PowerUpWeights{Spread: 1}}},
+		{name: "no weights", drops: PowerUpDrops{DropChance: 100}},
+		{name: "only spread", drops: PowerUpDrops{DropChance: 100, Weights: PowerUpWeights{Spread: 1}}, wantKind: PowerUpSpread},
+		{name: "only shield", drops: PowerUpDrops{DropChance: 100, Weights: PowerUpWeights{Shield: 3}}, wantKind: PowerUpShield},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewGame("drop", 7, testLevels(t))
+			g.Stage.PowerUps = tt.drops
+			rng := g.RNG
+
+			g.dropPowerUp(at)
+			if tt.wantKind == "" {
+				if len(g.PowerUps) != 0 {
+					t.Fatalf("dropped %v, want nothing", g.PowerUps)
+				}
+				// Replays rely on levels without drops not drawing numbers
+				if g.RNG != rng {
+					t.Error("a level without drops used the random sequence")
+				}
+				return
+			}
+			if len(g.PowerUps) != 1 {
+				t.Fatalf("dropped %d items, want 1", len(g.PowerUps))
+			}
+			item := g.PowerUps[0]
+			if item.Kind != tt.wantKind || item.Position != at || !item.Active {
+				t.Errorf("dropped %s at %v, want an active %s at %v", item.Kind, item.Position, tt.wantKind, at)
+			}
+		})
+	}
+}
+
+func TestMovePowerUps(t *testing.T) {
+	tests := []struct {
+		name      string
+		offset    Position
+		collected bool
+		falling   bool
+	}{
+		{name: "lands on the ship", offset: Position{Y: -powerUpFallSpeed}, collected: true},
+		{name: "falls beside the ship", offset: Position{X: 100, Y: -200}, falling: true},
+		{name: "falls off the field", offset: Position{X: 100, Y: playerRowOffset}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewGame("fall", 7, testLevels(t))
+			ship := g.Ships[0].Position
+			start := Position{X: ship.X + tt.offset.X, Y: ship.Y + tt.offset.Y}
+			g.PowerUps = []PowerUp{{GameObject: GameObject{ID: "item", Position: start, Active: true}, Kind: PowerUpShield}}
+
+			g.movePowerUps()
+			if got := g.Ships[0].hasEffect(PowerUpShield); got != tt.collected {
+				t.Errorf("ship shielded = %v, want %v", got, tt.collected)
+			}
+			if tt.falling != (len(g.PowerUps) == 1) {
+				t.Fatalf("items left = %v, want falling %v", g.PowerUps, tt.falling)
+			}
+			if tt.falling && g.PowerUps[0].Position.Y != start.Y+powerUpFallSpeed {
+				t.Errorf("item at y %d, want %d", g.PowerUps[0].Position.Y, start.Y+powerUpFallSpeed)
+			}
+		})
+	}
+}
+
+func TestCollect(t *testing.T) {
+	tests := []struct {
+		name        string
+		lives       int
+		effects     []Effect
+		kind        string
+		wantLives   int
+		wantEffects []Effect
+	}{
+		{name: "extra life", lives: 2, kind: PowerUpExtraLife, wantLives: 3},
+		{name: "extra life at the cap", lives: maxLives, kind: PowerUpExtraLife, wantLives: maxLives},
+		{
+			name: "new effect", lives: 2, kind: PowerUpRapidFire, wantLives: 2,
+			wantEffects: []Effect{{Kind: PowerUpRapidFire, ExpiresAt: 100 + effectTicks}},
+		},
+		{
+			name: "same effect restarts its timer", lives: 2, kind: PowerUpSpread, wantLives: 2,
+			effects:     []Effect{{Kind: PowerUpSpread, ExpiresAt: 120}, {Kind: PowerUpShield, ExpiresAt: 130}},
+			wantEffects: []Effect{{Kind: PowerUpSpread, ExpiresAt: 100 + effectTicks}, {Kind: PowerUpShield, ExpiresAt: 130}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewGame("collect", 7, testLevels(t))
+			g.Tick = 100
+			g.Lives = tt.lives
+			ship := &g.Ships[0]
+			ship.Effects = tt.effects
+
+			g.collect(ship, tt.kind)
+			if g.Lives != tt.wantLives {
+				t.Errorf("lives = %d, want %d", g.Lives, tt.wantLives)
+			}
+			if !slices.Equal(ship.Effects, tt.wantEffects) {
+				t.Errorf("effects = %v, want %v", ship.Effects, tt.wantEffects)
+			}
+		})
+	}
+}
+
+func TestExpireEffects(t *testing.T) {
+	tests := []struct {
+		tick uint64
+		want []Effect
+	}{
+		{tick: 99, want: []Effect{{Kind: PowerUpSpread, ExpiresAt: 100}, {Kind: PowerUpShield, ExpiresAt: 200}}},
+		// An effect is gone on the tick it expires at
+		{tick: 100, want: []Effect{{Kind: PowerUpShield, ExpiresAt: 200}}},
+		{tick: 200},
+	}
+
+	for _, tt := range tests {
+		g := NewGame("expire", 7, testLevels(t))
+		g.Tick = tt.tick
+		ship := &g.Ships[0]
+		ship.Effects = []Effect{{Kind: PowerUpSpread, ExpiresAt: 100}, {Kind: PowerUpShield, ExpiresAt: 200}}
+
+		g.expireEffects(ship)
+		if !slices.Equal(ship.Effects, tt.want) {
+			t.Errorf("effects at tick %d = %v, want %v", tt.tick, ship.Effects, tt.want)
+		}
+	}
+}
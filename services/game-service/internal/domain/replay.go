@@ -0,0 +1,108 @@
+package domain
+
+import (
+	"bytes"
+	"encoding/json"
+	"time"
+)
+
+// InputRecord is an input as it was applied, keyed by the tick it ran on
+type InputRecord struct {
+	Tick      uint64 `json:"tick"`
+	Ship      int    `json:"ship,omitempty"`
+	Action    string `json:"action"`
+	Direction string `json:"direction,omitempty"`
+}
+
+// Replay is everything needed to re-run a game from scratch
+type Replay struct {
+	GameID    string        `json:"game_id"`
+	Seed      uint64        `json:"seed"`
+	FinalTick uint64        `json:"final_tick"`
+	Score     int           `json:"score"`
+	Status    GameStatus    `json:"status"`
+	Inputs    []InputRecord `json:"inputs"`
+}
+
+type ReplayResult struct {
+	GameID        string `json:"game_id"`
+	ReportedScore int    `json:"reported_score"`
+	ReplayedScore int    `json:"replayed_score"`
+	ReplayedTick  uint64 `json:"replayed_tick"`
+	ScoreMatches  bool   `json:"score_matches"`
+	// StateMatches is only set when verifying against a live game
+	StateMatches *bool `json:"state_matches,omitempty"`
+}
+
+// Replay exports the seed and input log of the game
+func (g *Game) Replay() Replay {
+	return Replay{
+		GameID:    g.ID,
+		Seed:      g.Seed,
+		FinalTick: g.Tick,
+		Score:     g.Score,
+		Status:    g.Status,
+		Inputs:    append([]InputRecord{}, g.Inputs...),
+	}
+}
+
+// Rerun rebuilds a game from its seed by feeding the logged inputs back in on
+// the ticks they were originally applied.
+func Rerun(r Replay, levels *LevelSet) *Game {
+	g := NewGame(r.GameID, r.Seed, levels)
+
+	next := 0
+	for g.Status == StatusActive && g.Tick < r.FinalTick {
+		for next < len(r.Inputs) && r.Inputs[next].Tick <= g.Tick {
+			in := r.Inputs[next]
+			if in.Tick == g.Tick {
+				g.ApplyInput(Input{Ship: in.Ship, Action: in.Action, Direction: in.Direction})
+			}
+			next++
+		}
+		g.Update()
+	}
+	return g
+}
+
+// VerifyReplay re-runs the replay and checks it reaches the reported score
+func VerifyReplay(r Replay, levels *LevelSet) ReplayResult {
+	return replayResult(r, Rerun(r, levels))
+}
+
+// Verify re-runs the game's own log and additionally requires the replayed
+// simulation state to be byte-identical to the live one.
+func (g *Game) Verify() ReplayResult {
+	r := g.Replay()
+	replayed := Rerun(r, g.levels)
+	result := replayResult(r, replayed)
+	matches := bytes.Equal(g.simulationState(), replayed.simulationState())
+	result.StateMatches = &matches
+	return result
+}
+
+func replayResult(r Replay, replayed *Game) ReplayResult {
+	return ReplayResult{
+		GameID:        r.GameID,
+		ReportedScore: r.Score,
+		ReplayedScore: replayed.Score,
+		ReplayedTick:  replayed.Tick,
+		ScoreMatches:  replayed.Score == r.Score,
+	}
+}
+
+// simulationState serializes the game without wall-clock fields, player
+// identities, seats and anti-cheat flags, the only state not derived from the
+// seed and inputs.
+func (g *Game) simulationState() []byte {
+	state := *g
+	state.CreatedAt = time.Time{}
+	state.LastInputAt = time.Time{}
+	state.EndedAt = nil
+	state.PlayerID = ""
+	state.PlayerName = ""
+	state.Seats = nil
+	state.Flags = nil
+	data, _ := json.Marshal(state)
+	return data
+}
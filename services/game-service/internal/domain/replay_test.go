@@ -0,0 +1,109 @@
+package domain
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+)
+
+// testLevels is a single level with every feature that draws on the random
+// sequence or spawns entities, followed by a boss on every second level
+func testLevels(t *testing.T) *LevelSet {
+	t.Helper()
+	level := LevelDef{
+		Number:       1,
+		World:        World{Width: 800, Height: 600},
+		Pattern:      PatternSweep,
+		EnemySpeed:   2,
+		FireInterval: 40,
+		BulletSpeed:  6,
+		Bonus:        100,
+		PowerUps: PowerUpDrops{
+			DropChance: 50,
+			Weights:    PowerUpWeights{Spread: 1, RapidFire: 1, Shield: 1, ExtraLife: 1},
+		},
+		Grid:    &GridLayout{Count: 12, Columns: 6, Origin: Position{X: 150, Y: 60}, SpacingX: 80, SpacingY: 50},
+		Bunkers: &BunkerLayout{Count: 3, Y: 460, Columns: 8, Rows: 4, CellSize: 8},
+	}
+	boss := &BossDef{
+		Every:  2,
+		HP:     10,
+		Reward: 500,
+		Phases: []BossPhase{
+			{Health: 100, Attack: BossAttackSingle, FireInterval: 20, Speed: 2},
+			{Health: 50, Attack: BossAttackSpread, FireInterval: 15, Speed: 4},
+		},
+	}
+	levels, err := NewLevelSet([]LevelDef{level}, boss)
+	if err != nil {
+		t.Fatalf("building level set: %v", err)
+	}
+	return levels
+}
+
+// play sweeps ship 0 from side to side, firing as often as it can, for up to
+// ticks ticks
+func play(g *Game, ticks int) {
+	for i := 0; i < ticks && g.Status == StatusActive; i++ {
+		direction := "left"
+		if (i/40)%2 == 1 {
+			direction = "right"
+		}
+		g.ApplyInput(Input{Action: "move", Direction: direction})
+		if i%3 == 0 {
+			g.ApplyInput(Input{Action: "shoot"})
+		}
+		g.Update()
+	}
+}
+
+func TestRerunReproducesSimulationState(t *testing.T) {
+	levels := testLevels(t)
+	g := NewGame("determinism", 42, levels)
+	play(g, 3000)
+	// Reaching the boss level covers level changes, drops and bunkers too
+	if g.Level < 2 || len(g.Inputs) == 0 {
+		t.Fatalf("the game did not get far enough to be worth replaying: level %d, %d inputs", g.Level, len(g.Inputs))
+	}
+
+	replayed := Rerun(g.Replay(), levels)
+	if !bytes.Equal(g.simulationState(), replayed.simulationState()) {
+		t.Errorf("replayed state differs: live tick %d score %d, replayed tick %d score %d",
+			g.Tick, g.Score, replayed.Tick, replayed.Score)
+	}
+}
+
+func TestRerunFromDownloadedReplay(t *testing.T) {
+	levels := testLevels(t)
+	g := NewGame("download", 7, levels)
+	play(g, 1500)
+
+	data, err := json.Marshal(g.Replay())
+	if err != nil {
+		t.Fatalf("encoding replay: %v", err)
+	}
+	var replay Replay
+	if err := json.Unmarshal(data, &replay); err != nil {
+		t.Fatalf("decoding replay: %v", err)
+	}
+
+	replayed := Rerun(replay, levels)
+	if !bytes.Equal(g.simulationState(), replayed.simulationState()) {
+		t.Error("state replayed from the downloaded replay differs from the live game")
+	}
+	if result := VerifyReplay(replay, levels); !result.ScoreMatches {
+		t.Errorf("VerifyReplay = %+v, want a matching score", result)
+	}
+}
+
+func TestVerifyDetectsTampering(t *testing.T) {
+	levels := testLevels(t)
+	g := NewGame("tampered", 42, levels)
+	play(g, 1500)
+
+	g.Score += 1000
+	result := g.Verify()
+	if result.ScoreMatches || result.StateMatches == nil || *result.StateMatches {
+		t.Errorf("Verify = %+v, want the score and state to mismatch", result)
+	}
+}
@@ -0,0 +1,16 @@
+package domain
+
+// nextRandom steps a splitmix64 generator. Its whole state is one exported field
+// on Game, so a seeded game serializes, restores and replays deterministically.
+func (g *Game) nextRandom() uint64 {
+	g.RNG += 0x9e3779b97f4a7c15
+	z := g.RNG
+	z = (z ^ (z >> 30)) * 0xbf58476d1ce4e5b9
+	z = (z ^ (z >> 27)) * 0x94d049bb133111eb
+	return z ^ (z >> 31)
+}
+
+// randomIntn returns a value in [0, n)
+func (g *Game) randomIntn(n int) int {
+	return int(g.nextRandom() % uint64(n))
+}
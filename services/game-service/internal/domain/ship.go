@@ -0,0 +1,201 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+// MaxShips is how many players can share one game
+const MaxShips = 4
+
+// Spawn columns in eighths of the world width, by slot. Slot 0 spawns in the
+// centre so single-player games look as they always have.
+var spawnEighths = [MaxShips]int{4, 2, 6, 1}
+
+// Ship is one player's cannon. Ships are indexed by slot; a ship whose player
+// has left stays in the slice, inactive, so slots never shift.
+type Ship struct {
+	GameObject
+	Slot              int          `json:"slot"`
+	Bullets           []GameObject `json:"bullets"`
+	Score             int          `json:"score"`
+	FireCooldown      int          `json:"fire_cooldown"`
+	InvulnerableTicks int          `json:"invulnerable_ticks"`
+	// Effects are the ship's active power-ups
+	Effects []Effect `json:"effects,omitempty"`
+}
+
+// Seat ties a player to a ship slot. Seats are service bookkeeping: the
+// simulation only sees the join and leave inputs they produce.
+type Seat struct {
+	Slot     int       `json:"slot"`
+	PlayerID string    `json:"player_id,omitempty"`
+	Name     string    `json:"name"`
+	JoinedAt time.Time `json:"joined_at"`
+	Left     bool      `json:"left,omitempty"`
+	// AckedSeq is the last client sequence number handled for the seat
+	AckedSeq uint64 `json:"acked_seq,omitempty"`
+}
+
+// SeatOf returns the slot of the player's current seat
+func (g *Game) SeatOf(playerID string) (int, error) {
+	for _, seat := range g.Seats {
+		if seat.PlayerID == playerID && !seat.Left {
+			return seat.Slot, nil
+		}
+	}
+	return 0, ErrNotSeated
+}
+
+// You tells a client which seat is its own. Snapshots carry no player ids,
+// so states sent to a seated player add it.
+type You struct {
+	Slot     int    `json:"slot"`
+	AckedSeq uint64 `json:"acked_seq"`
+}
+
+// YouOf returns the player's seat as sent to them, or nil when they hold none
+func (g *Game) YouOf(playerID string) *You {
+	slot, err := g.SeatOf(playerID)
+	if err != nil {
+		return nil
+	}
+	return &You{Slot: slot, AckedSeq: g.Seats[slot].AckedSeq}
+}
+
+// AckedSeq returns the last sequence number handled for the player's seat
+func (g *Game) AckedSeq(playerID string) uint64 {
+	slot, err := g.SeatOf(playerID)
+	if err != nil {
+		return 0
+	}
+	return g.Seats[slot].AckedSeq
+}
+
+// CheckSeqs compares client sequence numbers, oldest first, with the last one
+// acknowledged for the seat in slot. It returns how many leading seqs were
+// acknowledged already, whose inputs are retries to drop, and fails with a
+// *SequenceError when the rest do not follow on one by one. Seq 0 marks an
+// input from a client that does not number its inputs.
+func (g *Game) CheckSeqs(slot int, seqs []uint64) (int, error) {
+	acked := g.Seats[slot].AckedSeq
+	next := acked + 1
+	duplicates := 0
+	for i, seq := range seqs {
+		switch {
+		case seq == 0:
+		case seq < next && i == duplicates:
+			duplicates++
+		case seq != next:
+			return 0, &SequenceError{Seq: seq, Acked: acked}
+		default:
+			next++
+		}
+	}
+	return duplicates, nil
+}
+
+// AckSeq records seq as handled for the seat in slot
+func (g *Game) AckSeq(slot int, seq uint64) {
+	if seq > g.Seats[slot].AckedSeq {
+		g.Seats[slot].AckedSeq = seq
+	}
+}
+
+// TakeSeat seats the player, giving back their old slot when they rejoin.
+// spawn reports whether a join input must be queued to bring the ship in;
+// it is false when the player is already seated.
+func (g *Game) TakeSeat(playerID, name string, now time.Time) (slot int, spawn bool, err error) {
+	for i := range g.Seats {
+		seat := &g.Seats[i]
+		if seat.PlayerID != playerID {
+			continue
+		}
+		if !seat.Left {
+			return seat.Slot, false, nil
+		}
+		seat.Left = false
+		seat.Name = name
+		seat.JoinedAt = now
+		return seat.Slot, true, nil
+	}
+
+	if len(g.Seats) >= MaxShips {
+		return 0, false, ErrGameFull
+	}
+	slot = len(g.Seats)
+	g.Seats = append(g.Seats, Seat{Slot: slot, PlayerID: playerID, Name: name, JoinedAt: now})
+	return slot, true, nil
+}
+
+// LeaveSeat frees the player's seat and returns the slot whose ship must be
+// removed with a leave input.
+func (g *Game) LeaveSeat(playerID string) (int, error) {
+	for i := range g.Seats {
+		seat := &g.Seats[i]
+		if seat.PlayerID == playerID && !seat.Left {
+			seat.Left = true
+			return seat.Slot, nil
+		}
+	}
+	return 0, ErrNotSeated
+}
+
+// joinShip brings the ship for slot into play at its spawn point
+func (g *Game) joinShip(slot int) error {
+	for len(g.Ships) <= slot {
+		g.Ships = append(g.Ships, newShip(len(g.Ships)))
+	}
+
+	ship := &g.Ships[slot]
+	if ship.Active {
+		return ErrInvalidMove
+	}
+	ship.Active = true
+	ship.Bullets = make([]GameObject, 0)
+	ship.FireCooldown = 0
+	ship.InvulnerableTicks = invulnerabilityTicks
+	g.spawnShip(ship)
+	return nil
+}
+
+func (g *Game) leaveShip(slot int) error {
+	ship, err := g.ship(slot)
+	if err != nil {
+		return err
+	}
+	ship.Active = false
+	ship.Bullets = make([]GameObject, 0)
+	return nil
+}
+
+// ship returns the ship in slot if it is in play
+func (g *Game) ship(slot int) (*Ship, error) {
+	if slot < 0 || slot >= len(g.Ships) || !g.Ships[slot].Active {
+		return nil, ErrNotSeated
+	}
+	return &g.Ships[slot], nil
+}
+
+func (g *Game) spawnShip(ship *Ship) {
+	x := g.Stage.World.Width*spawnEighths[ship.Slot]/8 - playerWidth/2
+	ship.Position = Position{X: x, Y: g.playerRow()}
+}
+
+func newShip(slot int) Ship {
+	return Ship{
+		GameObject: GameObject{ID: fmt.Sprintf("player%d", slot+1)},
+		Slot:       slot,
+		Bullets:    make([]GameObject, 0),
+	}
+}
+
+func cloneShips(ships []Ship) []Ship {
+	clone := make([]Ship, len(ships))
+	for i, ship := range ships {
+		clone[i] = ship
+		clone[i].Bullets = cloneObjects(ship.Bullets)
+		clone[i].Effects = append([]Effect(nil), ship.Effects...)
+	}
+	return clone
+}
@@ -0,0 +1,71 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+)
+
+func seatedGame(t *testing.T, acked uint64) *Game {
+	t.Helper()
+	g := NewGame("seqs", 1, testLevels(t))
+	g.Seats = []Seat{{Slot: 0, PlayerID: "player", AckedSeq: acked}}
+	return g
+}
+
+func TestCheckSeqs(t *testing.T) {
+	tests := []struct {
+		name       string
+		acked      uint64
+		seqs       []uint64
+		duplicates int
+		outOfOrder bool
+	}{
+		{name: "first input", seqs: []uint64{1}},
+		{name: "next batch", acked: 4, seqs: []uint64{5, 6, 7}},
+		{name: "whole batch resent", acked: 7, seqs: []uint64{5, 6, 7}, duplicates: 3},
+		{name: "batch resent with new inputs", acked: 6, seqs: []uint64{5, 6, 7, 8}, duplicates: 2},
+		{name: "unnumbered inputs", acked: 3, seqs: []uint64{0, 0}},
+		{name: "unnumbered between numbered", acked: 3, seqs: []uint64{4, 0, 5}},
+		{name: "gap", acked: 3, seqs: []uint64{5}, outOfOrder: true},
+		{name: "gap inside batch", acked: 3, seqs: []uint64{4, 6}, outOfOrder: true},
+		{name: "repeat inside batch", acked: 3, seqs: []uint64{4, 4}, outOfOrder: true},
+		{name: "swapped", acked: 3, seqs: []uint64{5, 4}, outOfOrder: true},
+		{name: "old seq after new ones", acked: 3, seqs: []uint64{4, 2}, outOfOrder: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := seatedGame(t, tt.acked)
+			duplicates, err := g.CheckSeqs(0, tt.seqs)
+			if tt.outOfOrder {
+				var seqErr *SequenceError
+				if !errors.As(err, &seqErr) || !errors.Is(err, ErrOutOfOrder) {
+					t.Fatalf("got %v, want a sequence error", err)
+				}
+				if seqErr.Acked != tt.acked {
+					t.Errorf("error reports acked %d, want %d", seqErr.Acked, tt.acked)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if duplicates != tt.duplicates {
+				t.Errorf("duplicates = %d, want %d", duplicates, tt.duplicates)
+			}
+		})
+	}
+}
+
+func TestAckSeqNeverGoesBack(t *testing.T) {
+	g := seatedGame(t, 0)
+	for _, seq := range []uint64{1, 2, 5, 3, 0} {
+		g.AckSeq(0, seq)
+	}
+	if acked := g.AckedSeq("player"); acked != 5 {
+		t.Errorf("acked seq = %d, want 5", acked)
+	}
+	if acked := g.AckedSeq("stranger"); acked != 0 {
+		t.Errorf("acked seq of an unseated player = %d, want 0", acked)
+	}
+}
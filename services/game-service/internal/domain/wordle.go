@@ -0,0 +1,150 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Wordle rules
+const (
+	WordLength = 5
+	MaxGuesses = 6
+)
+
+var ErrInvalidGuess = NewError("invalid_guess", "guess must be a five-letter word")
+
+// LetterResult is the feedback for one letter of a guess
+type LetterResult string
+
+const (
+	LetterCorrect LetterResult = "correct"
+	LetterPresent LetterResult = "present"
+	LetterAbsent  LetterResult = "absent"
+)
+
+// GuessResult is a guess and the feedback for each of its letters
+type GuessResult struct {
+	Word    string         `json:"word"`
+	Letters []LetterResult `json:"letters"`
+}
+
+// WordleGame is one player's attempt at a day's word. Word is only sent to
+// clients once the game is over; see Snapshot.
+type WordleGame struct {
+	ID         string        `json:"id"`
+	PlayerID   string        `json:"player_id,omitempty"`
+	PlayerName string        `json:"player_name"`
+	Day        string        `json:"day"`
+	Word       string        `json:"word,omitempty"`
+	Guesses    []GuessResult `json:"guesses"`
+	Status     GameStatus    `json:"status"`
+	CreatedAt  time.Time     `json:"created_at"`
+	// LastGuessAt is unset until the first guess
+	LastGuessAt *time.Time `json:"last_guess_at,omitempty"`
+	EndedAt     *time.Time `json:"ended_at,omitempty"`
+}
+
+// NewWordleGame starts a game for the word of day, a date as YYYY-MM-DD
+func NewWordleGame(id, day, word string) *WordleGame {
+	return &WordleGame{
+		ID:        id,
+		Day:       day,
+		Word:      word,
+		Guesses:   make([]GuessResult, 0, MaxGuesses),
+		Status:    StatusActive,
+		CreatedAt: time.Now(),
+	}
+}
+
+// NormalizeWord upper-cases word and reports whether it is five letters A-Z
+func NormalizeWord(word string) (string, bool) {
+	word = strings.ToUpper(strings.TrimSpace(word))
+	if len(word) != WordLength {
+		return "", false
+	}
+	for i := 0; i < len(word); i++ {
+		if word[i] < 'A' || word[i] > 'Z' {
+			return "", false
+		}
+	}
+	return word, true
+}
+
+// Guess scores word against the answer. The game is won on an exact match
+// and lost when the sixth guess misses.
+func (g *WordleGame) Guess(word string) (GuessResult, error) {
+	if g.Status != StatusActive {
+		return GuessResult{}, ErrGameOver
+	}
+	guess, ok := NormalizeWord(word)
+	if !ok {
+		return GuessResult{}, fmt.Errorf("%w: got %q", ErrInvalidGuess, word)
+	}
+
+	result := GuessResult{Word: guess, Letters: scoreGuess(g.Word, guess)}
+	g.Guesses = append(g.Guesses, result)
+	now := time.Now()
+	g.LastGuessAt = &now
+
+	switch {
+	case guess == g.Word:
+		g.finish(StatusWon)
+	case len(g.Guesses) >= MaxGuesses:
+		g.finish(StatusLost)
+	}
+	return result, nil
+}
+
+func (g *WordleGame) finish(status GameStatus) {
+	g.Status = status
+	g.EndedAt = g.LastGuessAt
+}
+
+// Clone returns a deep copy of the game, answer included
+func (g *WordleGame) Clone() *WordleGame {
+	clone := *g
+	clone.Guesses = make([]GuessResult, len(g.Guesses), MaxGuesses)
+	for i, guess := range g.Guesses {
+		clone.Guesses[i] = GuessResult{Word: guess.Word, Letters: append([]LetterResult(nil), guess.Letters...)}
+	}
+	return &clone
+}
+
+// Snapshot returns a copy safe to send to clients, with the player id left
+// out and the answer hidden while the game is still in progress
+func (g *WordleGame) Snapshot() *WordleGame {
+	snapshot := g.Clone()
+	snapshot.PlayerID = ""
+	if g.Status == StatusActive {
+		snapshot.Word = ""
+	}
+	return snapshot
+}
+
+// scoreGuess marks exact matches first, then marks other letters present
+// only as often as the answer has them unmatched, so a repeated letter in a
+// guess is not reported present more times than it appears.
+func scoreGuess(answer, guess string) []LetterResult {
+	letters := make([]LetterResult, WordLength)
+	var unmatched [26]int
+	for i := 0; i < WordLength; i++ {
+		if guess[i] == answer[i] {
+			letters[i] = LetterCorrect
+		} else {
+			unmatched[answer[i]-'A']++
+		}
+	}
+	for i := 0; i < WordLength; i++ {
+		if letters[i] != "" {
+			continue
+		}
+		if unmatched[guess[i]-'A'] > 0 {
+			unmatched[guess[i]-'A']--
+			letters[i] = LetterPresent
+		} else {
+			letters[i] = LetterAbsent
+		}
+	}
+	return letters
+}
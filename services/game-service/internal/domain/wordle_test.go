@@ -0,0 +1,32 @@
+package domain
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestScoreGuess(t *testing.T) {
+	c, p, a := LetterCorrect, LetterPresent, LetterAbsent
+	tests := []struct {
+		answer, guess string
+		want          []LetterResult
+	}{
+		{"HELLO", "HELLO", []LetterResult{c, c, c, c, c}},
+		{"CRANE", "TOUGH", []LetterResult{a, a, a, a, a}},
+		// Both Ls are in the answer, elsewhere
+		{"HELLO", "LLAMA", []LetterResult{p, p, a, a, a}},
+		// The answer's only E goes to the exact match
+		{"HELLO", "LEVEL", []LetterResult{p, c, a, a, p}},
+		// An exact match wins the letter over an earlier present
+		{"CRANE", "EERIE", []LetterResult{a, a, p, a, c}},
+		{"ROBOT", "OOOOO", []LetterResult{a, c, a, c, a}},
+		{"SPEED", "ERASE", []LetterResult{p, a, a, p, p}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.answer+"/"+tt.guess, func(t *testing.T) {
+			if got := scoreGuess(tt.answer, tt.guess); !slices.Equal(got, tt.want) {
+				t.Errorf("scoreGuess(%s, %s) = %v, want %v", tt.answer, tt.guess, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,26 @@
+package domain
+
+import "time"
+
+// DayLayout formats the day a Wordle game belongs to
+const DayLayout = "2006-01-02"
+
+// dailyWords is cycled through one word per day when no DAILY_WORD is
+// configured. Append only: reordering changes every future day's word.
+var dailyWords = []string{
+	"CLOUD", "BRAVE", "CRANE", "PLANT", "GHOST", "STORM", "LIGHT", "MONEY",
+	"RIVER", "PIANO", "SHIFT", "TRAIN", "WORLD", "QUEEN", "FLAME", "GRAPE",
+	"HOUSE", "NIGHT", "OCEAN", "PRIDE", "ROBOT", "SMILE", "TIGER", "UNITY",
+	"VOICE", "WHEAT", "YOUTH", "ZEBRA", "BLAST", "CHARM", "DREAM", "EAGLE",
+	"FROST", "GLOBE", "HEART", "IVORY", "JELLY", "KNIFE", "LEMON", "MAPLE",
+	"NOBLE", "ORBIT", "PEARL", "QUILT", "RADAR", "SOLAR", "TORCH", "ULTRA",
+	"VIVID", "WALTZ", "BENCH", "CANDY", "DELTA", "EMBER", "FAIRY", "GIANT",
+	"HONEY", "INDEX", "JOLLY", "KARMA", "LUNAR", "MIRTH", "NERVE", "OLIVE",
+}
+
+// DailyWord picks the word list entry for day, counting days since the Unix
+// epoch in UTC so every instance agrees on the word.
+func DailyWord(day time.Time) string {
+	days := day.UTC().Unix() / (24 * 60 * 60)
+	return dailyWords[int(days%int64(len(dailyWords)))]
+}
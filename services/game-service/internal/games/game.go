@@ -0,0 +1,126 @@
+// Package games defines the interface every game type hosted by the game
+// service implements, and the registry the types add themselves to.
+package games
+
+import (
+	"fmt"
+	"time"
+
+	"portfolio-game-service/internal/domain"
+	"portfolio-game-service/internal/leaderboard"
+)
+
+var (
+	ErrUnknownType  = domain.NewError("unknown_game_type", "unknown game type")
+	ErrNotSupported = domain.NewError("not_supported", "not supported by this game type")
+	// ErrPlayerRequired is returned when an anonymous player starts a game
+	// of a type that is kept per player
+	ErrPlayerRequired = domain.NewError("player_required", "this game type needs a player id")
+)
+
+// Action is a command from a player. Each type reads the fields its actions
+// take and rejects action names it does not know.
+type Action struct {
+	Name      string
+	Direction string
+	Word      string
+}
+
+// ActionError reports which action of a batch was rejected
+type ActionError struct {
+	Index int
+	Err   error
+}
+
+func (e *ActionError) Error() string {
+	return fmt.Sprintf("action %d: %v", e.Index, e.Err)
+}
+
+func (e *ActionError) Unwrap() error {
+	return e.Err
+}
+
+// Info is the bookkeeping the service keeps for a game of any type
+type Info struct {
+	ID     string
+	Type   string
+	Status domain.GameStatus
+	// PlayerID is the player who started the game
+	PlayerID    string
+	CreatedAt   time.Time
+	LastInputAt time.Time
+	EndedAt     *time.Time
+}
+
+// Game is a game of a registered type. GameService serializes every call, so
+// implementations do no locking of their own. Games encode to JSON as the
+// state clients see and storage keeps.
+type Game interface {
+	Info() Info
+	// SeatOf returns the slot the player's actions apply to
+	SeatOf(playerID string) (int, error)
+	// Apply checks actions from the player in slot and applies them in order,
+	// or queues them for the next Tick. A batch is all or nothing.
+	Apply(slot int, actions []Action) error
+	// Tick advances a realtime game by one step
+	Tick()
+	// Snapshot returns a copy safe to hand to clients
+	Snapshot() Game
+}
+
+// Restorer is implemented by games that need the environment back after
+// being decoded from storage
+type Restorer interface {
+	Restore(env Env)
+}
+
+// Sequencer is implemented by games that track client input sequence numbers
+// per seat; see domain.Game.CheckSeqs.
+type Sequencer interface {
+	CheckSeqs(slot int, seqs []uint64) (int, error)
+	AckSeq(slot int, seq uint64)
+}
+
+// Viewer is implemented by games more than one player can be seated in.
+// Snapshots carry no player ids, so a player is sent a View, which also says
+// which seat is theirs.
+type Viewer interface {
+	View(playerID string) Game
+	// You returns the seat a view was taken for, or nil
+	You() *domain.You
+}
+
+// Cloner is implemented by games that can copy their whole stored state, so
+// the copy can be written out while the original keeps changing
+type Cloner interface {
+	Clone() Game
+}
+
+// Streamer is implemented by games that send subscribers a frame after every
+// tick
+type Streamer interface {
+	// Frame returns a snapshot of the game as a frame
+	Frame() *domain.Game
+}
+
+// Verifier is implemented by games whose outcome can be checked by replaying
+// their input log
+type Verifier interface {
+	Verify() domain.ReplayResult
+}
+
+// Scorer is implemented by games that put their players on the leaderboard
+// once they end
+type Scorer interface {
+	// Entries returns an entry for every player who saw the game through
+	Entries() []leaderboard.Entry
+}
+
+// Flagger is implemented by games the anti-cheat checks can flag. Flagged
+// games are kept off the leaderboard.
+type Flagger interface {
+	// Flag adds reason unless the game already has it, and reports whether
+	// it was added
+	Flag(reason string) bool
+	Flags() []string
+}
@@ -0,0 +1,218 @@
+package games
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"portfolio-game-service/internal/domain"
+	"portfolio-game-service/internal/leaderboard"
+	"portfolio-game-service/pkg/metrics"
+)
+
+const TypeInvaders = "invaders"
+
+// Inputs beyond this many per game are rejected until the next tick drains the queue
+const maxPendingInputs = 32
+
+func init() {
+	Register(Type{
+		Name:     TypeInvaders,
+		Realtime: true,
+		Actions: []ActionSpec{
+			{Name: "move", Param: ParamDirection},
+			{Name: "shoot"},
+			{Name: "update"},
+		},
+		New:    newInvaders,
+		Decode: decodeInvaders,
+	})
+}
+
+// InvadersGame is the arcade shooter. Inputs are queued and applied at the
+// start of the next tick, so every seat sees them land in arrival order.
+type InvadersGame struct {
+	Game    *domain.Game
+	pending []domain.Input
+}
+
+func newInvaders(id string, player Player, env Env) (Game, error) {
+	game := domain.NewGame(id, randomSeed(), env.Levels)
+	game.PlayerID = player.ID
+	game.PlayerName = player.Name
+	game.Seats = []domain.Seat{{PlayerID: player.ID, Name: player.Name, JoinedAt: game.CreatedAt}}
+	return &InvadersGame{Game: game}, nil
+}
+
+func decodeInvaders(data []byte) (Game, error) {
+	var game domain.Game
+	if err := json.Unmarshal(data, &game); err != nil {
+		return nil, err
+	}
+	return &InvadersGame{Game: &game}, nil
+}
+
+// Restore gives a game loaded from storage the level set it needs to progress
+func (g *InvadersGame) Restore(env Env) {
+	g.Game.Restore(env.Levels)
+}
+
+func (g *InvadersGame) Info() Info {
+	return Info{
+		ID:          g.Game.ID,
+		Type:        TypeInvaders,
+		Status:      g.Game.Status,
+		PlayerID:    g.Game.PlayerID,
+		CreatedAt:   g.Game.CreatedAt,
+		LastInputAt: g.Game.LastInputAt,
+		EndedAt:     g.Game.EndedAt,
+	}
+}
+
+func (g *InvadersGame) SeatOf(playerID string) (int, error) {
+	return g.Game.SeatOf(playerID)
+}
+
+// Apply checks inputs for the ship in slot and queues them for the next
+// tick. Update only checks that the game is still running.
+func (g *InvadersGame) Apply(slot int, actions []Action) error {
+	inputs := make([]domain.Input, 0, len(actions))
+	for i, action := range actions {
+		// Seats change only through Join and Leave
+		if action.Name == "join" || action.Name == "leave" {
+			metrics.InvalidInputs.WithLabelValues(TypeInvaders).Inc()
+			return &ActionError{Index: i, Err: fmt.Errorf("%w: unknown action %q", domain.ErrInvalidMove, action.Name)}
+		}
+
+		input := domain.Input{Ship: slot, Action: action.Name, Direction: action.Direction}
+		if err := g.Game.ValidateInput(input); err != nil {
+			metrics.InvalidInputs.WithLabelValues(TypeInvaders).Inc()
+			return &ActionError{Index: i, Err: err}
+		}
+		if action.Name != "update" {
+			inputs = append(inputs, input)
+		}
+	}
+
+	if err := g.queue(inputs...); err != nil {
+		return err
+	}
+	metrics.InputsTotal.WithLabelValues(TypeInvaders).Add(float64(len(inputs)))
+	return nil
+}
+
+// Join spawns the ship of a newly seated player on the next tick
+func (g *InvadersGame) Join(slot int) error {
+	return g.queue(domain.Input{Ship: slot, Action: "join"})
+}
+
+// Leave removes the ship in slot on the next tick
+func (g *InvadersGame) Leave(slot int) error {
+	return g.queue(domain.Input{Ship: slot, Action: "leave"})
+}
+
+func (g *InvadersGame) queue(inputs ...domain.Input) error {
+	if len(inputs) == 0 {
+		return nil
+	}
+	if len(g.pending)+len(inputs) > maxPendingInputs {
+		metrics.InvalidInputs.WithLabelValues(TypeInvaders).Inc()
+		return domain.ErrInputBacklog
+	}
+	g.pending = append(g.pending, inputs...)
+	g.Game.LastInputAt = time.Now()
+	return nil
+}
+
+// Tick applies queued inputs in arrival order, then advances the simulation
+func (g *InvadersGame) Tick() {
+	for _, input := range g.pending {
+		if err := g.Game.ApplyInput(input); err != nil {
+			metrics.InvalidInputs.WithLabelValues(TypeInvaders).Inc()
+		}
+	}
+	g.pending = nil
+
+	g.Game.Update()
+	if g.Game.Status != domain.StatusActive {
+		endedAt := time.Now()
+		g.Game.EndedAt = &endedAt
+	}
+}
+
+func (g *InvadersGame) Snapshot() Game {
+	return &InvadersGame{Game: g.Game.Snapshot()}
+}
+
+func (g *InvadersGame) View(playerID string) Game {
+	return &InvadersGame{Game: g.Game.View(playerID)}
+}
+
+func (g *InvadersGame) You() *domain.You {
+	return g.Game.You
+}
+
+// Clone copies the stored state. Queued inputs are not stored and stay behind.
+func (g *InvadersGame) Clone() Game {
+	return &InvadersGame{Game: g.Game.Clone()}
+}
+
+func (g *InvadersGame) Frame() *domain.Game {
+	return g.Game.Snapshot()
+}
+
+func (g *InvadersGame) Verify() domain.ReplayResult {
+	return g.Game.Verify()
+}
+
+// Entries gives every seat that did not leave the team's combined score
+func (g *InvadersGame) Entries() []leaderboard.Entry {
+	achievedAt := time.Now().UTC()
+	if g.Game.EndedAt != nil {
+		achievedAt = g.Game.EndedAt.UTC()
+	}
+
+	var entries []leaderboard.Entry
+	for _, seat := range g.Game.Seats {
+		if seat.Left {
+			continue
+		}
+		entries = append(entries, leaderboard.Entry{
+			GameID:     g.Game.ID,
+			PlayerID:   seat.PlayerID,
+			Player:     seat.Name,
+			Score:      g.Game.Score,
+			Level:      g.Game.Level,
+			AchievedAt: achievedAt,
+		})
+	}
+	return entries
+}
+
+func (g *InvadersGame) Flag(reason string) bool {
+	return g.Game.Flag(reason)
+}
+
+func (g *InvadersGame) Flags() []string {
+	return g.Game.Flags
+}
+
+func (g *InvadersGame) CheckSeqs(slot int, seqs []uint64) (int, error) {
+	return g.Game.CheckSeqs(slot, seqs)
+}
+
+func (g *InvadersGame) AckSeq(slot int, seq uint64) {
+	g.Game.AckSeq(slot, seq)
+}
+
+func (g *InvadersGame) MarshalJSON() ([]byte, error) {
+	return json.Marshal(g.Game)
+}
+
+func randomSeed() uint64 {
+	bytes := make([]byte, 8)
+	rand.Read(bytes)
+	return binary.BigEndian.Uint64(bytes)
+}
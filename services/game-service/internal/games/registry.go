@@ -0,0 +1,154 @@
+package games
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"portfolio-game-service/internal/domain"
+)
+
+// Default is the type of games started without one, and of games stored
+// before there were types
+const Default = TypeInvaders
+
+// Parameters an action can take
+const (
+	ParamDirection = "direction"
+	ParamWord      = "word"
+)
+
+// ActionSpec names an action and the parameter it requires, if any
+type ActionSpec struct {
+	Name  string
+	Param string
+}
+
+// Player is who starts a game. ID is empty for anonymous players.
+type Player struct {
+	ID   string
+	Name string
+}
+
+// Env holds what game types are built with
+type Env struct {
+	Levels *domain.LevelSet
+	// DailyWord replaces the word list for daily word games when set
+	DailyWord string
+}
+
+// Type describes a game type. Types register themselves from init.
+type Type struct {
+	Name string
+	// Realtime games are ticked by the game loop and checkpointed once per
+	// persist interval, or sooner when they end or players join, leave or get
+	// flagged; other games are saved after each action
+	Realtime bool
+	// Daily games are one per player per UTC day: starting again returns the
+	// day's game, which is kept until the day is over unless it is left idle.
+	// Only identified players can start them.
+	Daily   bool
+	Actions []ActionSpec
+	New     func(id string, player Player, env Env) (Game, error)
+	// Decode restores a game from the JSON it encodes to
+	Decode func(data []byte) (Game, error)
+}
+
+var (
+	registry   = make(map[string]Type)
+	registryMu sync.RWMutex
+)
+
+// Register adds a game type. It panics when the name is taken or an action
+// name is already used by another type, since actions are routed by name.
+func Register(t Type) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+
+	if _, exists := registry[t.Name]; exists {
+		panic(fmt.Sprintf("games: type %q registered twice", t.Name))
+	}
+	for _, other := range registry {
+		for _, action := range t.Actions {
+			if _, taken := findAction(other, action.Name); taken {
+				panic(fmt.Sprintf("games: action %q of %q already used by %q", action.Name, t.Name, other.Name))
+			}
+		}
+	}
+	registry[t.Name] = t
+}
+
+// Lookup returns the registered type called name
+func Lookup(name string) (Type, error) {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	t, exists := registry[name]
+	if !exists {
+		return Type{}, fmt.Errorf("%w: %q", ErrUnknownType, name)
+	}
+	return t, nil
+}
+
+// Names lists the registered types in alphabetical order
+func Names() []string {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	names := make([]string, 0, len(registry))
+	for name := range registry {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// LookupAction finds the action called name among all registered types
+func LookupAction(name string) (ActionSpec, bool) {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	for _, t := range registry {
+		if spec, ok := findAction(t, name); ok {
+			return spec, true
+		}
+	}
+	return ActionSpec{}, false
+}
+
+// ActionNames lists every registered action in alphabetical order
+func ActionNames() []string {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	var names []string
+	for _, t := range registry {
+		for _, action := range t.Actions {
+			names = append(names, action.Name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Decode restores a stored game of the named type. An empty name is the
+// default type.
+func Decode(typeName string, data []byte) (Game, error) {
+	if typeName == "" {
+		typeName = Default
+	}
+	t, err := Lookup(typeName)
+	if err != nil {
+		return nil, err
+	}
+	return t.Decode(data)
+}
+
+func findAction(t Type, name string) (ActionSpec, bool) {
+	for _, action := range t.Actions {
+		if action.Name == name {
+			return action, true
+		}
+	}
+	return ActionSpec{}, false
+}
@@ -0,0 +1,104 @@
+package games
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"portfolio-game-service/internal/domain"
+	"portfolio-game-service/pkg/metrics"
+)
+
+const TypeWordle = "wordle"
+
+func init() {
+	Register(Type{
+		Name:  TypeWordle,
+		Daily: true,
+		Actions: []ActionSpec{
+			{Name: "guess", Param: ParamWord},
+		},
+		New:    newWordle,
+		Decode: decodeWordle,
+	})
+}
+
+// WordleGame is the daily word game. Guesses are scored as they arrive.
+type WordleGame struct {
+	Game *domain.WordleGame
+}
+
+func newWordle(id string, player Player, env Env) (Game, error) {
+	now := time.Now().UTC()
+	word := env.DailyWord
+	if word == "" {
+		word = domain.DailyWord(now)
+	}
+	game := domain.NewWordleGame(id, now.Format(domain.DayLayout), word)
+	game.PlayerID = player.ID
+	game.PlayerName = player.Name
+	return &WordleGame{Game: game}, nil
+}
+
+func decodeWordle(data []byte) (Game, error) {
+	var game domain.WordleGame
+	if err := json.Unmarshal(data, &game); err != nil {
+		return nil, err
+	}
+	return &WordleGame{Game: &game}, nil
+}
+
+func (g *WordleGame) Info() Info {
+	lastInputAt := g.Game.CreatedAt
+	if g.Game.LastGuessAt != nil {
+		lastInputAt = *g.Game.LastGuessAt
+	}
+	return Info{
+		ID:          g.Game.ID,
+		Type:        TypeWordle,
+		Status:      g.Game.Status,
+		PlayerID:    g.Game.PlayerID,
+		CreatedAt:   g.Game.CreatedAt,
+		LastInputAt: lastInputAt,
+		EndedAt:     g.Game.EndedAt,
+	}
+}
+
+// SeatOf only seats the player who started the game. Anonymous players have
+// no way to tell their games apart, so they are never seated.
+func (g *WordleGame) SeatOf(playerID string) (int, error) {
+	if playerID == "" || playerID != g.Game.PlayerID {
+		return 0, domain.ErrNotSeated
+	}
+	return 0, nil
+}
+
+// Apply scores guesses on a copy and keeps it only when every guess counts
+func (g *WordleGame) Apply(slot int, actions []Action) error {
+	next := g.Game.Clone()
+	for i, action := range actions {
+		if action.Name != "guess" {
+			metrics.InvalidGuesses.Inc()
+			return &ActionError{Index: i, Err: fmt.Errorf("%w: unknown action %q", domain.ErrInvalidMove, action.Name)}
+		}
+		if _, err := next.Guess(action.Word); err != nil {
+			metrics.InvalidGuesses.Inc()
+			return &ActionError{Index: i, Err: err}
+		}
+	}
+
+	g.Game = next
+	metrics.GuessesTotal.Add(float64(len(actions)))
+	return nil
+}
+
+// Tick does nothing: the game only changes when the player guesses
+func (g *WordleGame) Tick() {}
+
+func (g *WordleGame) Snapshot() Game {
+	return &WordleGame{Game: g.Game.Snapshot()}
+}
+
+func (g *WordleGame) MarshalJSON() ([]byte, error) {
+	return json.Marshal(g.Game)
+}
@@ -2,32 +2,101 @@ package handlers
 
 import (
 	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
 	"net/http"
+	"strconv"
 
 	"portfolio-game-service/internal/domain"
+	"portfolio-game-service/internal/games"
+	"portfolio-game-service/internal/leaderboard"
 	"portfolio-game-service/internal/services"
 
 	"github.com/sirupsen/logrus"
 )
 
+// Player identity is established by the frontend's signed session cookie and
+// forwarded on every request in these headers.
+const (
+	PlayerIDHeader   = "X-Player-ID"
+	PlayerNameHeader = "X-Player-Name"
+)
+
+// AckedSeqHeader echoes the last input sequence number handled for the
+// caller's seat on move responses, so clients can tell which inputs landed
+const AckedSeqHeader = "X-Acked-Seq"
+
+const (
+	defaultHistoryLimit = 20
+	maxHistoryLimit     = 100
+)
+
+const (
+	maxReplayBytes = 8 << 20
+	// Nearly two hours of play at the default tick rate. Longer games can
+	// still be checked by id, from the log the service kept.
+	maxReplayTicks = 1 << 16
+)
+
 type GameHandler struct {
 	gameService *services.GameService
 	logger      *logrus.Logger
 }
 
+// StartGameRequest starts a game of Type, one of the registered game types,
+// or of the default type when it is empty
+type StartGameRequest struct {
+	Player string `json:"player,omitempty"`
+	Type   string `json:"type,omitempty"`
+}
+
 type StartGameResponse struct {
 	GameID string `json:"game_id"`
+	Type   string `json:"type"`
 	Status string `json:"status"`
 }
 
+// MoveRequest is one action. Direction and Word are the parameters of the
+// actions that take them.
 type MoveRequest struct {
 	GameID    string `json:"game_id"`
+	Seq       uint64 `json:"seq,omitempty"`
 	Action    string `json:"action"`
 	Direction string `json:"direction,omitempty"`
+	Word      string `json:"word,omitempty"`
+}
+
+// BatchMoveRequest is the body of /game/moves: the inputs a client buffered
+// over one frame, oldest first
+type BatchMoveRequest struct {
+	GameID string      `json:"game_id"`
+	Inputs []MoveInput `json:"inputs"`
+}
+
+// MoveInput is one buffered input. Seq numbers the client's inputs and
+// ClientTime is the client clock in Unix milliseconds.
+type MoveInput struct {
+	Seq        uint64 `json:"seq"`
+	ClientTime int64  `json:"client_time,omitempty"`
+	Action     string `json:"action"`
+	Direction  string `json:"direction,omitempty"`
+	Word       string `json:"word,omitempty"`
+}
+
+// SeatRequest is the body of join and leave requests
+type SeatRequest struct {
+	GameID string `json:"game_id"`
 }
 
-type ErrorResponse struct {
-	Error string `json:"error"`
+type JoinResponse struct {
+	GameID string `json:"game_id"`
+	Slot   int    `json:"slot"`
+}
+
+type HistoryResponse struct {
+	PlayerID string              `json:"player_id"`
+	Games    []leaderboard.Entry `json:"games"`
 }
 
 func NewGameHandler(gameService *services.GameService, logger *logrus.Logger) *GameHandler {
@@ -44,15 +113,46 @@ func (h *GameHandler) Health(w http.ResponseWriter, r *http.Request) {
 }
 
 func (h *GameHandler) StartGame(w http.ResponseWriter, r *http.Request) {
-	game, err := h.gameService.StartGame()
+	// The body is optional; older clients start games without one
+	var req StartGameRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+		h.writeError(w, r, errInvalidBody)
+		return
+	}
+
+	playerID, ok := playerIdentity(r)
+	if !ok {
+		h.writeError(w, r, errInvalidPlayerID)
+		return
+	}
+
+	// The session nickname wins over one sent in the body
+	name := req.Player
+	if header := r.Header.Get(PlayerNameHeader); header != "" {
+		name = header
+	}
+	player, ok := domain.NormalizePlayerName(name)
+	if !ok {
+		h.writeError(w, r, errInvalidPlayerName)
+		return
+	}
+
+	gameType := req.Type
+	if gameType == "" {
+		gameType = games.Default
+	}
+
+	game, err := h.gameService.StartGame(gameType, playerID, player)
 	if err != nil {
-		h.writeError(w, "Failed to start game", http.StatusInternalServerError)
+		h.writeError(w, r, err)
 		return
 	}
 
+	info := game.Info()
 	response := StartGameResponse{
-		GameID: game.ID,
-		Status: string(game.Status),
+		GameID: info.ID,
+		Type:   info.Type,
+		Status: string(info.Status),
 	}
 
 	h.writeJSON(w, response, http.StatusCreated)
@@ -61,48 +161,298 @@ func (h *GameHandler) StartGame(w http.ResponseWriter, r *http.Request) {
 func (h *GameHandler) MakeMove(w http.ResponseWriter, r *http.Request) {
 	var req MoveRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		h.writeError(w, "Invalid request body", http.StatusBadRequest)
+		h.writeError(w, r, errInvalidBody)
+		return
+	}
+
+	playerID, ok := playerIdentity(r)
+	if !ok {
+		h.writeError(w, r, errInvalidPlayerID)
+		return
+	}
+
+	since, hasSince, err := sinceParam(r)
+	if err != nil {
+		h.writeError(w, r, err)
 		return
 	}
 
-	game, err := h.gameService.MakeMove(req.GameID, req.Action, req.Direction)
+	action := games.Action{Name: req.Action, Direction: req.Direction, Word: req.Word}
+	game, err := h.gameService.MakeMove(req.GameID, playerID, action, req.Seq)
 	if err != nil {
-		switch err {
-		case domain.ErrGameNotFound:
-			h.writeError(w, "Game not found", http.StatusNotFound)
-		case domain.ErrGameOver:
-			h.writeError(w, "Game is over", http.StatusBadRequest)
-		case domain.ErrInvalidMove:
-			h.writeError(w, "Invalid move", http.StatusBadRequest)
-		default:
-			h.writeError(w, "Internal server error", http.StatusInternalServerError)
+		h.writeError(w, r, err)
+		return
+	}
+
+	setAckedSeq(w, game)
+	h.writeState(w, game, since, hasSince)
+}
+
+// MakeMoves queues a batch of inputs at once and answers with one state
+func (h *GameHandler) MakeMoves(w http.ResponseWriter, r *http.Request) {
+	var req BatchMoveRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.writeError(w, r, errInvalidBody)
+		return
+	}
+
+	playerID, ok := playerIdentity(r)
+	if !ok {
+		h.writeError(w, r, errInvalidPlayerID)
+		return
+	}
+
+	since, hasSince, err := sinceParam(r)
+	if err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+
+	moves := make([]services.Move, len(req.Inputs))
+	for i, input := range req.Inputs {
+		moves[i] = services.Move{
+			Seq:        input.Seq,
+			ClientTime: input.ClientTime,
+			Action:     games.Action{Name: input.Action, Direction: input.Direction, Word: input.Word},
 		}
+	}
+
+	game, err := h.gameService.MakeMoves(req.GameID, playerID, moves)
+	if err != nil {
+		h.writeError(w, r, err)
 		return
 	}
 
-	h.writeJSON(w, game, http.StatusOK)
+	setAckedSeq(w, game)
+	h.writeState(w, game, since, hasSince)
+}
+
+// JoinGame adds the caller's ship to a running game for co-op play
+func (h *GameHandler) JoinGame(w http.ResponseWriter, r *http.Request) {
+	var req SeatRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.GameID == "" {
+		h.writeError(w, r, errInvalidBody)
+		return
+	}
+
+	// Seats are keyed by player id, so anonymous players cannot join
+	playerID, ok := playerIdentity(r)
+	if !ok || playerID == "" {
+		h.writeError(w, r, errSessionRequired)
+		return
+	}
+	player, ok := domain.NormalizePlayerName(r.Header.Get(PlayerNameHeader))
+	if !ok {
+		h.writeError(w, r, errInvalidPlayerName)
+		return
+	}
+
+	game, slot, err := h.gameService.JoinGame(req.GameID, playerID, player)
+	if err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+
+	h.writeJSON(w, JoinResponse{GameID: game.Info().ID, Slot: slot}, http.StatusOK)
+}
+
+func (h *GameHandler) LeaveGame(w http.ResponseWriter, r *http.Request) {
+	var req SeatRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.GameID == "" {
+		h.writeError(w, r, errInvalidBody)
+		return
+	}
+	playerID, ok := playerIdentity(r)
+	if !ok {
+		h.writeError(w, r, errInvalidPlayerID)
+		return
+	}
+
+	if err := h.gameService.LeaveGame(req.GameID, playerID); err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
 }
 
 func (h *GameHandler) GetStatus(w http.ResponseWriter, r *http.Request) {
 	gameID := r.URL.Query().Get("game_id")
 	if gameID == "" {
-		h.writeError(w, "Missing game_id parameter", http.StatusBadRequest)
+		h.writeError(w, r, errMissingGameID)
+		return
+	}
+
+	since, hasSince, err := sinceParam(r)
+	if err != nil {
+		h.writeError(w, r, err)
 		return
 	}
 
 	game, err := h.gameService.GetGameStatus(gameID)
 	if err != nil {
-		if err == domain.ErrGameNotFound {
-			h.writeError(w, "Game not found", http.StatusNotFound)
-		} else {
-			h.writeError(w, "Internal server error", http.StatusInternalServerError)
+		h.writeError(w, r, err)
+		return
+	}
+
+	h.writeState(w, game, since, hasSince)
+}
+
+// ActiveGame returns the caller's game in progress so it can be resumed. The
+// type query parameter picks the game type, the default type when absent.
+func (h *GameHandler) ActiveGame(w http.ResponseWriter, r *http.Request) {
+	playerID, ok := playerIdentity(r)
+	if !ok || playerID == "" {
+		h.writeError(w, r, errSessionRequired)
+		return
+	}
+
+	gameType := r.URL.Query().Get("type")
+	if gameType == "" {
+		gameType = games.Default
+	}
+	if _, err := games.Lookup(gameType); err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+
+	game, err := h.gameService.ActiveGame(playerID, gameType)
+	if err != nil {
+		if errors.Is(err, domain.ErrGameNotFound) {
+			err = fmt.Errorf("%w: player has no game in progress", err)
 		}
+		h.writeError(w, r, err)
 		return
 	}
 
 	h.writeJSON(w, game, http.StatusOK)
 }
 
+// History lists the caller's finished games, newest first
+func (h *GameHandler) History(w http.ResponseWriter, r *http.Request) {
+	playerID, ok := playerIdentity(r)
+	if !ok || playerID == "" {
+		h.writeError(w, r, errSessionRequired)
+		return
+	}
+
+	limit := defaultHistoryLimit
+	if raw := r.URL.Query().Get("limit"); raw != "" {
+		var err error
+		limit, err = strconv.Atoi(raw)
+		if err != nil || limit < 1 || limit > maxHistoryLimit {
+			h.writeError(w, r, errInvalidLimit)
+			return
+		}
+	}
+
+	games, err := h.gameService.PlayerHistory(playerID, limit)
+	if err != nil {
+		h.writeError(w, r, fmt.Errorf("read player history: %w", err))
+		return
+	}
+	if games == nil {
+		games = []leaderboard.Entry{}
+	}
+
+	h.writeJSON(w, HistoryResponse{PlayerID: playerID, Games: games}, http.StatusOK)
+}
+
+func (h *GameHandler) GetReplay(w http.ResponseWriter, r *http.Request) {
+	gameID := r.URL.Query().Get("game_id")
+	if gameID == "" {
+		h.writeError(w, r, errMissingGameID)
+		return
+	}
+
+	replay, err := h.gameService.GetReplay(gameID)
+	if err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Disposition", `attachment; filename="replay-`+replay.GameID+`.json"`)
+	h.writeJSON(w, replay, http.StatusOK)
+}
+
+// VerifyReplay checks a stored game when given game_id, or an uploaded replay
+// document posted as the request body.
+func (h *GameHandler) VerifyReplay(w http.ResponseWriter, r *http.Request) {
+	if r.Method == http.MethodGet {
+		gameID := r.URL.Query().Get("game_id")
+		if gameID == "" {
+			h.writeError(w, r, errMissingGameID)
+			return
+		}
+
+		result, err := h.gameService.VerifyGame(gameID)
+		if err != nil {
+			h.writeError(w, r, err)
+			return
+		}
+		h.writeJSON(w, result, http.StatusOK)
+		return
+	}
+
+	var replay domain.Replay
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxReplayBytes)).Decode(&replay); err != nil {
+		h.writeError(w, r, errInvalidBody)
+		return
+	}
+	if replay.FinalTick > maxReplayTicks {
+		h.writeError(w, r, errReplayTooLong)
+		return
+	}
+
+	result, err := h.gameService.VerifyReplay(replay)
+	if err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+	h.writeJSON(w, result, http.StatusOK)
+}
+
+// writeState sends the full game, or for arcade games a frame relative to the
+// client's last seq when it asked for one with ?since=
+func (h *GameHandler) writeState(w http.ResponseWriter, game games.Game, since uint64, hasSince bool) {
+	arcade, ok := game.(*games.InvadersGame)
+	if !hasSince || !ok {
+		h.writeJSON(w, game, http.StatusOK)
+		return
+	}
+	frame := h.gameService.FrameSince(arcade.Game, since)
+	frame.You = arcade.Game.You
+	h.writeJSON(w, frame, http.StatusOK)
+}
+
+// setAckedSeq sets AckedSeqHeader from the caller's seat in a view
+func setAckedSeq(w http.ResponseWriter, game games.Game) {
+	if viewer, ok := game.(games.Viewer); ok && viewer.You() != nil {
+		w.Header().Set(AckedSeqHeader, strconv.FormatUint(viewer.You().AckedSeq, 10))
+	}
+}
+
+func sinceParam(r *http.Request) (uint64, bool, error) {
+	raw := r.URL.Query().Get("since")
+	if raw == "" {
+		return 0, false, nil
+	}
+	since, err := strconv.ParseUint(raw, 10, 64)
+	if err != nil {
+		return 0, false, errInvalidSince
+	}
+	return since, true, nil
+}
+
+// playerIdentity reads the player id forwarded by the frontend
+func playerIdentity(r *http.Request) (string, bool) {
+	id := r.Header.Get(PlayerIDHeader)
+	if !domain.ValidPlayerID(id) {
+		return "", false
+	}
+	return id, true
+}
+
 func (h *GameHandler) writeJSON(w http.ResponseWriter, data interface{}, status int) {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(status)
@@ -111,13 +461,6 @@ func (h *GameHandler) writeJSON(w http.ResponseWriter, data interface{}, status
 	}
 }
 
-func (h *GameHandler) writeError(w http.ResponseWriter, message string, status int) {
-	h.logger.WithFields(logrus.Fields{
-		"error": message,
-		"status": status,
-	}).Error("Request error")
-	
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(status)
-	json.NewEncoder(w).Encode(ErrorResponse{Error: message})
-}
\ No newline at end of file
+func (h *GameHandler) writeError(w http.ResponseWriter, r *http.Request, err error) {
+	writeProblem(w, r, h.logger, err)
+}
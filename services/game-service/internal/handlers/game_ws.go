@@ -0,0 +1,252 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"portfolio-game-service/internal/domain"
+	"portfolio-game-service/internal/games"
+	"portfolio-game-service/pkg/metrics"
+
+	"github.com/gorilla/websocket"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	wsWriteWait  = 10 * time.Second
+	wsPongWait   = 60 * time.Second
+	wsPingPeriod = (wsPongWait * 9) / 10
+	// Every this many frames a full state is sent even if no delta was lost
+	wsKeyframeInterval = 50
+)
+
+// wsSyncAction asks the socket for a keyframe after the client missed a delta
+const wsSyncAction = "sync"
+
+var upgrader = websocket.Upgrader{
+	ReadBufferSize:  1024,
+	WriteBufferSize: 4096,
+}
+
+// WSCommand is a player input sent over the game socket
+type WSCommand struct {
+	Seq       uint64 `json:"seq,omitempty"`
+	Action    string `json:"action"`
+	Direction string `json:"direction,omitempty"`
+}
+
+// WSFrame is pushed to the client for every state change or rejected
+// command. State frames are keyframes; delta frames apply to the last frame
+// the client received.
+type WSFrame struct {
+	domain.Frame
+	Error string `json:"error,omitempty"`
+	// Code is the problem code of a rejected command, as in HTTP responses
+	Code string `json:"code,omitempty"`
+	// AckedSeq is set when a command was rejected as out of order
+	AckedSeq *uint64 `json:"acked_seq,omitempty"`
+}
+
+const (
+	rolePlayer    = "player"
+	roleSpectator = "spectator"
+)
+
+func (h *GameHandler) GameSocket(w http.ResponseWriter, r *http.Request) {
+	gameID := r.URL.Query().Get("game_id")
+	if gameID == "" {
+		h.writeError(w, r, errMissingGameID)
+		return
+	}
+
+	playerID, ok := playerIdentity(r)
+	if !ok {
+		h.writeError(w, r, errInvalidPlayerID)
+		return
+	}
+
+	frames, unsubscribe, err := h.gameService.Subscribe(gameID)
+	if err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+	defer unsubscribe()
+
+	you := func() *domain.You {
+		return h.gameService.You(gameID, playerID)
+	}
+	h.serveSocket(w, r, gameID, frames, rolePlayer, you, func(cmd WSCommand) error {
+		action := games.Action{Name: cmd.Action, Direction: cmd.Direction}
+		_, err := h.gameService.MakeMove(gameID, playerID, action, cmd.Seq)
+		return err
+	})
+}
+
+// SpectateSocket streams an active game read-only. Commands sent by a
+// spectator are answered with an error frame and never reach the game.
+func (h *GameHandler) SpectateSocket(w http.ResponseWriter, r *http.Request) {
+	gameID := r.URL.Query().Get("game_id")
+	if gameID == "" {
+		h.writeError(w, r, errMissingGameID)
+		return
+	}
+
+	frames, unsubscribe, err := h.gameService.Spectate(gameID)
+	if err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+	defer unsubscribe()
+
+	noSeat := func() *domain.You { return nil }
+	h.serveSocket(w, r, gameID, frames, roleSpectator, noSeat, func(WSCommand) error {
+		return errSpectatorMove
+	})
+}
+
+// FeaturedGame returns the best-scoring game in progress for spectators
+func (h *GameHandler) FeaturedGame(w http.ResponseWriter, r *http.Request) {
+	game, err := h.gameService.FeaturedGame()
+	if err != nil {
+		if errors.Is(err, domain.ErrGameNotFound) {
+			err = fmt.Errorf("%w: nobody is playing right now", err)
+		}
+		h.writeError(w, r, err)
+		return
+	}
+
+	h.writeJSON(w, game, http.StatusOK)
+}
+
+// serveSocket upgrades the connection and pushes frames until the game ends
+// or the client goes away. Every frame carries the seat you returns, and
+// incoming commands are passed to handle.
+func (h *GameHandler) serveSocket(w http.ResponseWriter, r *http.Request, gameID string, frames <-chan *domain.Game, role string, you func() *domain.You, handle func(WSCommand) error) {
+	conn, err := upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		h.logger.WithError(err).Warn("WebSocket upgrade failed")
+		return
+	}
+	defer conn.Close()
+
+	metrics.SocketConnections.WithLabelValues(role).Inc()
+	defer metrics.SocketConnections.WithLabelValues(role).Dec()
+
+	log := h.logger.WithFields(logrus.Fields{
+		"game_id": gameID,
+		"role":    role,
+	})
+	log.Debug("WebSocket client connected")
+
+	rejected := make(chan WSFrame, 8)
+	resync := make(chan struct{}, 1)
+	done := make(chan struct{})
+	go h.readCommands(conn, handle, rejected, resync, done, log)
+
+	// last is the state the client holds; deltas are computed against it
+	var last *domain.Game
+	sent := 0
+
+	ping := time.NewTicker(wsPingPeriod)
+	defer ping.Stop()
+
+	for {
+		select {
+		case <-done:
+			log.Debug("WebSocket client disconnected")
+			return
+		case game, ok := <-frames:
+			if !ok {
+				h.closeSocket(conn, "game expired")
+				return
+			}
+			frame := domain.KeyFrame(game)
+			if last != nil && sent%wsKeyframeInterval != 0 {
+				if delta, ok := domain.DeltaFrame(last, game); ok {
+					frame = delta
+				}
+			}
+			frame.You = you()
+			if err := h.writeFrame(conn, WSFrame{Frame: frame}); err != nil {
+				return
+			}
+			last = game
+			sent++
+			if game.Status != domain.StatusActive {
+				h.closeSocket(conn, "game over")
+				return
+			}
+		case frame := <-rejected:
+			if err := h.writeFrame(conn, frame); err != nil {
+				return
+			}
+		case <-resync:
+			if last == nil {
+				continue
+			}
+			frame := domain.KeyFrame(last)
+			frame.You = you()
+			if err := h.writeFrame(conn, WSFrame{Frame: frame}); err != nil {
+				return
+			}
+			sent = 1
+		case <-ping.C:
+			conn.SetWriteDeadline(time.Now().Add(wsWriteWait))
+			if err := conn.WriteMessage(websocket.PingMessage, nil); err != nil {
+				return
+			}
+		}
+	}
+}
+
+func (h *GameHandler) readCommands(conn *websocket.Conn, handle func(WSCommand) error, rejected chan<- WSFrame, resync chan<- struct{}, done chan<- struct{}, log *logrus.Entry) {
+	defer close(done)
+
+	conn.SetReadLimit(1024)
+	conn.SetReadDeadline(time.Now().Add(wsPongWait))
+	conn.SetPongHandler(func(string) error {
+		return conn.SetReadDeadline(time.Now().Add(wsPongWait))
+	})
+
+	for {
+		var cmd WSCommand
+		if err := conn.ReadJSON(&cmd); err != nil {
+			if websocket.IsUnexpectedCloseError(err, websocket.CloseGoingAway, websocket.CloseNormalClosure) {
+				log.WithError(err).Warn("WebSocket read failed")
+			}
+			return
+		}
+
+		if cmd.Action == wsSyncAction {
+			select {
+			case resync <- struct{}{}:
+			default:
+			}
+			continue
+		}
+
+		if err := handle(cmd); err != nil {
+			problem := newProblem(err)
+			select {
+			case rejected <- WSFrame{Frame: domain.Frame{Type: "error"}, Error: problem.Title, Code: problem.Code, AckedSeq: problem.AckedSeq}:
+			default:
+			}
+		}
+	}
+}
+
+func (h *GameHandler) writeFrame(conn *websocket.Conn, frame WSFrame) error {
+	conn.SetWriteDeadline(time.Now().Add(wsWriteWait))
+	if err := conn.WriteJSON(frame); err != nil {
+		h.logger.WithError(err).Debug("WebSocket write failed")
+		return err
+	}
+	return nil
+}
+
+func (h *GameHandler) closeSocket(conn *websocket.Conn, reason string) {
+	message := websocket.FormatCloseMessage(websocket.CloseNormalClosure, reason)
+	conn.WriteControl(websocket.CloseMessage, message, time.Now().Add(wsWriteWait))
+}
@@ -0,0 +1,100 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"portfolio-game-service/internal/leaderboard"
+
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	defaultLeaderboardLimit = 10
+	maxLeaderboardLimit     = 100
+)
+
+type LeaderboardHandler struct {
+	scores leaderboard.Store
+	logger *logrus.Logger
+}
+
+type LeaderboardResponse struct {
+	Period  string                    `json:"period"`
+	Entries []leaderboard.RankedEntry `json:"entries"`
+}
+
+func NewLeaderboardHandler(scores leaderboard.Store, logger *logrus.Logger) *LeaderboardHandler {
+	return &LeaderboardHandler{
+		scores: scores,
+		logger: logger,
+	}
+}
+
+// Top serves the best scores overall, today or this week (UTC)
+func (h *LeaderboardHandler) Top(w http.ResponseWriter, r *http.Request) {
+	period := r.URL.Query().Get("period")
+	if period == "" {
+		period = leaderboard.PeriodAll
+	}
+	since, err := leaderboard.PeriodStart(period, time.Now())
+	if err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+
+	limit := defaultLeaderboardLimit
+	if raw := r.URL.Query().Get("limit"); raw != "" {
+		limit, err = strconv.Atoi(raw)
+		if err != nil || limit < 1 || limit > maxLeaderboardLimit {
+			h.writeError(w, r, errInvalidLimit)
+			return
+		}
+	}
+
+	entries, err := h.scores.Top(limit, since)
+	if err != nil {
+		h.writeError(w, r, fmt.Errorf("read leaderboard: %w", err))
+		return
+	}
+
+	// Player ids are what the frontend acts on a player's behalf with, so
+	// the public table only shows nicknames
+	for i := range entries {
+		entries[i].PlayerID = ""
+	}
+
+	h.writeJSON(w, LeaderboardResponse{Period: period, Entries: entries}, http.StatusOK)
+}
+
+// Rank serves the caller's personal best and its overall position
+func (h *LeaderboardHandler) Rank(w http.ResponseWriter, r *http.Request) {
+	playerID, ok := playerIdentity(r)
+	if !ok || playerID == "" {
+		h.writeError(w, r, errSessionRequired)
+		return
+	}
+
+	best, err := h.scores.PersonalBest(playerID)
+	if err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+
+	h.writeJSON(w, best, http.StatusOK)
+}
+
+func (h *LeaderboardHandler) writeJSON(w http.ResponseWriter, data interface{}, status int) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(data); err != nil {
+		h.logger.WithError(err).Error("Failed to encode JSON response")
+	}
+}
+
+func (h *LeaderboardHandler) writeError(w http.ResponseWriter, r *http.Request, err error) {
+	writeProblem(w, r, h.logger, err)
+}
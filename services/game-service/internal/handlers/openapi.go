@@ -0,0 +1,19 @@
+package handlers
+
+import (
+	_ "embed"
+	"net/http"
+)
+
+// openAPISpec documents the /v1 routes. It is maintained by hand next to the
+// handlers, so update it together with any request or response struct.
+//
+//go:embed openapi.json
+var openAPISpec []byte
+
+// OpenAPI serves the OpenAPI 3 document for the REST API
+func OpenAPI(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(openAPISpec)
+}
@@ -0,0 +1,176 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	"portfolio-game-service/internal/domain"
+	"portfolio-game-service/internal/games"
+	"portfolio-game-service/internal/leaderboard"
+	"portfolio-game-service/internal/services"
+
+	"github.com/sirupsen/logrus"
+)
+
+// ProblemContentType is the media type of every error response (RFC 7807)
+const ProblemContentType = "application/problem+json"
+
+const problemTypeBase = "urn:game-service:problem:"
+
+// Errors raised by the handlers themselves, before a request reaches the
+// game service
+var (
+	errInvalidBody       = domain.NewError("invalid_body", "request body must be a JSON object")
+	errBodyTooLarge      = domain.NewError("body_too_large", "request body is too large")
+	errValidation        = domain.NewError("validation_failed", "request validation failed")
+	errMissingGameID     = domain.NewError("missing_game_id", "missing game_id parameter")
+	errInvalidPlayerID   = domain.NewError("invalid_player_id", "invalid player id")
+	errSessionRequired   = domain.NewError("session_required", "a player session is required")
+	errInvalidPlayerName = domain.NewError("invalid_player_name", "invalid player name")
+	errInvalidLimit      = domain.NewError("invalid_limit", "invalid limit, expected 1-100")
+	errReplayTooLong     = domain.NewError("replay_too_long", "replay is too long")
+	errInvalidSince      = domain.NewError("invalid_since", "since must be a tick number")
+	errSpectatorMove     = domain.NewError("spectator_move", "spectators cannot send moves")
+	errServiceToken      = domain.NewError("invalid_service_token", "missing or invalid service token")
+	errRouteNotFound     = domain.NewError("route_not_found", "no such endpoint")
+	errMethodNotAllowed  = domain.NewError("method_not_allowed", "method not allowed on this endpoint")
+	errInternal          = domain.NewError("internal_error", "internal server error")
+)
+
+// Problem is an RFC 7807 problem details body. Code is stable and meant for
+// clients to switch on; Title and Detail are for people.
+type Problem struct {
+	Type      string       `json:"type"`
+	Title     string       `json:"title"`
+	Status    int          `json:"status"`
+	Detail    string       `json:"detail,omitempty"`
+	Instance  string       `json:"instance,omitempty"`
+	Code      string       `json:"code"`
+	RequestID string       `json:"request_id,omitempty"`
+	Errors    []FieldError `json:"errors,omitempty"`
+	// AckedSeq tells a client whose input was out of order where to resume
+	AckedSeq *uint64 `json:"acked_seq,omitempty"`
+}
+
+// problemTypes maps known errors to a status and title. Lookups use
+// errors.Is, so errors wrapped with more detail keep their status.
+var problemTypes = []struct {
+	err    error
+	status int
+	title  string
+}{
+	{domain.ErrGameNotFound, http.StatusNotFound, "Game not found"},
+	{domain.ErrGameOver, http.StatusBadRequest, "Game is over"},
+	{domain.ErrGameActive, http.StatusConflict, "Game is still in progress"},
+	{domain.ErrInvalidMove, http.StatusBadRequest, "Invalid move"},
+	{domain.ErrInputBacklog, http.StatusTooManyRequests, "Too many pending inputs"},
+	{domain.ErrGameFull, http.StatusConflict, "Game is full"},
+	{domain.ErrNotSeated, http.StatusForbidden, "Player has not joined this game"},
+	{domain.ErrOutOfOrder, http.StatusConflict, "Input out of order"},
+	{domain.ErrInvalidGuess, http.StatusBadRequest, "Invalid guess"},
+	{games.ErrUnknownType, http.StatusBadRequest, "Unknown game type"},
+	{games.ErrNotSupported, http.StatusBadRequest, "Not supported by this game type"},
+	{games.ErrPlayerRequired, http.StatusBadRequest, "Game type needs a player id"},
+	{services.ErrReplaysBusy, http.StatusTooManyRequests, "Too many replays being verified"},
+	{leaderboard.ErrPlayerNotRanked, http.StatusNotFound, "Player has no ranked score"},
+	{leaderboard.ErrInvalidPeriod, http.StatusBadRequest, "Invalid period, expected all, day or week"},
+	{errInvalidBody, http.StatusBadRequest, "Invalid request body"},
+	{errBodyTooLarge, http.StatusRequestEntityTooLarge, "Request body too large"},
+	{errValidation, http.StatusBadRequest, "Request validation failed"},
+	{errMissingGameID, http.StatusBadRequest, "Missing game_id parameter"},
+	{errInvalidPlayerID, http.StatusBadRequest, "Invalid player id"},
+	{errSessionRequired, http.StatusBadRequest, "Missing or invalid player id"},
+	{errInvalidPlayerName, http.StatusBadRequest, "Invalid player name"},
+	{errInvalidLimit, http.StatusBadRequest, "Invalid limit"},
+	{errReplayTooLong, http.StatusBadRequest, "Replay is too long"},
+	{errInvalidSince, http.StatusBadRequest, "Invalid since parameter"},
+	{errSpectatorMove, http.StatusForbidden, "Spectators cannot send moves"},
+	{errServiceToken, http.StatusUnauthorized, "Missing or invalid service token"},
+	{errRouteNotFound, http.StatusNotFound, "Not found"},
+	{errMethodNotAllowed, http.StatusMethodNotAllowed, "Method not allowed"},
+}
+
+// validationError carries the field problems behind errValidation
+type validationError struct {
+	fields []FieldError
+}
+
+func (e *validationError) Error() string {
+	problems := make([]string, len(e.fields))
+	for i, field := range e.fields {
+		problems[i] = field.Field + " " + field.Message
+	}
+	return errValidation.Error() + ": " + strings.Join(problems, "; ")
+}
+
+func (e *validationError) Unwrap() error {
+	return errValidation
+}
+
+// newProblem describes err for a client. Unknown errors become a bare 500 so
+// internal details never leak.
+func newProblem(err error) Problem {
+	problem := Problem{
+		Status: http.StatusInternalServerError,
+		Title:  "Internal server error",
+		Code:   errInternal.Code,
+	}
+	for _, known := range problemTypes {
+		if errors.Is(err, known.err) {
+			problem.Status = known.status
+			problem.Title = known.title
+			problem.Code = domain.ErrorCode(known.err)
+			problem.Detail = err.Error()
+			break
+		}
+	}
+	problem.Type = problemTypeBase + problem.Code
+
+	var invalid *validationError
+	if errors.As(err, &invalid) {
+		problem.Errors = invalid.fields
+	}
+	var sequence *domain.SequenceError
+	if errors.As(err, &sequence) {
+		problem.AckedSeq = &sequence.Acked
+	}
+	return problem
+}
+
+// writeProblem logs err and sends it as application/problem+json
+func writeProblem(w http.ResponseWriter, r *http.Request, logger *logrus.Logger, err error) {
+	problem := newProblem(err)
+	problem.Instance = r.URL.Path
+	problem.RequestID = requestID(r)
+
+	log := logger.WithFields(logrus.Fields{
+		"code":       problem.Code,
+		"status":     problem.Status,
+		"request_id": problem.RequestID,
+	}).WithError(err)
+	if problem.Status >= http.StatusInternalServerError {
+		log.Error("Request failed")
+	} else {
+		log.Warn("Request rejected")
+	}
+
+	w.Header().Set("Content-Type", ProblemContentType)
+	w.WriteHeader(problem.Status)
+	json.NewEncoder(w).Encode(problem)
+}
+
+// NotFound answers requests for unknown paths with a problem body
+func NotFound(logger *logrus.Logger) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		writeProblem(w, r, logger, errRouteNotFound)
+	})
+}
+
+// MethodNotAllowed answers known paths requested with the wrong method
+func MethodNotAllowed(logger *logrus.Logger) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		writeProblem(w, r, logger, errMethodNotAllowed)
+	})
+}
@@ -0,0 +1,42 @@
+package handlers
+
+import (
+	"crypto/subtle"
+	"net/http"
+
+	"github.com/sirupsen/logrus"
+)
+
+// ServiceTokenHeader carries the secret game-service shares with the frontend
+const ServiceTokenHeader = "X-Service-Token"
+
+// Paths served without the token: load balancer health checks, metrics
+// scrapes and the API document
+var publicPaths = map[string]bool{
+	"/health":       true,
+	"/metrics":      true,
+	"/openapi.json": true,
+}
+
+// RequireServiceToken only lets through requests carrying token. Player ids
+// are taken from X-Player-ID as sent, so they must come from the frontend,
+// which fills them in from a signed session. An empty token turns the check
+// off.
+func RequireServiceToken(token string, logger *logrus.Logger, next http.Handler) http.Handler {
+	if token == "" {
+		return next
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !publicPaths[r.URL.Path] && !ValidServiceToken(token, r.Header.Get(ServiceTokenHeader)) {
+			writeProblem(w, r, logger, errServiceToken)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// ValidServiceToken compares a presented token with the expected one in
+// constant time
+func ValidServiceToken(token, presented string) bool {
+	return subtle.ConstantTimeCompare([]byte(token), []byte(presented)) == 1
+}
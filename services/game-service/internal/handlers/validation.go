@@ -0,0 +1,282 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"sort"
+	"strings"
+
+	"portfolio-game-service/internal/domain"
+	"portfolio-game-service/internal/games"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Request bodies are small JSON objects; anything bigger is rejected outright
+const maxRequestBytes = 64 << 10
+
+// maxBatchInputs caps one batch; a client flushing every frame sends far fewer
+const maxBatchInputs = 16
+
+// FieldError describes one problem with one field of a request body
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// bodyValidator checks a decoded JSON object and returns every problem found
+type bodyValidator func(fields map[string]json.RawMessage) []FieldError
+
+// validate rejects requests whose JSON body fails check before next sees
+// them. The body is restored so next can decode it as usual.
+func validate(logger *logrus.Logger, check bodyValidator, optional bool, next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxRequestBytes))
+		if err != nil {
+			writeProblem(w, r, logger, errBodyTooLarge)
+			return
+		}
+
+		if len(bytes.TrimSpace(body)) > 0 || !optional {
+			var fields map[string]json.RawMessage
+			if err := json.Unmarshal(body, &fields); err != nil || fields == nil {
+				writeProblem(w, r, logger, errInvalidBody)
+				return
+			}
+			if problems := check(fields); len(problems) > 0 {
+				writeProblem(w, r, logger, &validationError{fields: problems})
+				return
+			}
+		}
+
+		r.Body = io.NopCloser(bytes.NewReader(body))
+		next(w, r)
+	}
+}
+
+// ValidateMove checks move bodies so unknown actions and malformed
+// parameters never reach the game service
+func (h *GameHandler) ValidateMove(next http.HandlerFunc) http.HandlerFunc {
+	return validate(h.logger, validateMoveRequest, false, next)
+}
+
+// ValidateMoves checks batch move bodies, input by input
+func (h *GameHandler) ValidateMoves(next http.HandlerFunc) http.HandlerFunc {
+	return validate(h.logger, validateBatchMoveRequest, false, next)
+}
+
+// ValidateStart checks the optional start body
+func (h *GameHandler) ValidateStart(next http.HandlerFunc) http.HandlerFunc {
+	return validate(h.logger, validateStartGameRequest, true, next)
+}
+
+// ValidateSeat checks join and leave bodies
+func (h *GameHandler) ValidateSeat(next http.HandlerFunc) http.HandlerFunc {
+	return validate(h.logger, validateSeatRequest, false, next)
+}
+
+func validateMoveRequest(fields map[string]json.RawMessage) []FieldError {
+	v := fieldValidator{fields: fields}
+	v.known("game_id", "seq", "action", "direction", "word")
+	v.requiredString("game_id")
+	v.optionalUint("seq")
+	v.action()
+	return v.problems
+}
+
+func validateBatchMoveRequest(fields map[string]json.RawMessage) []FieldError {
+	v := fieldValidator{fields: fields}
+	v.known("game_id", "inputs")
+	v.requiredString("game_id")
+
+	var inputs []map[string]json.RawMessage
+	raw, ok := fields["inputs"]
+	switch {
+	case !ok || string(raw) == "null":
+		v.fail("inputs", "is required")
+	case json.Unmarshal(raw, &inputs) != nil:
+		v.fail("inputs", "must be an array of input objects")
+	case len(inputs) == 0 || len(inputs) > maxBatchInputs:
+		v.fail("inputs", fmt.Sprintf("must hold 1-%d inputs", maxBatchInputs))
+		inputs = nil
+	}
+
+	var lastSeq uint64
+	for i, input := range inputs {
+		if input == nil {
+			v.fail(fmt.Sprintf("inputs[%d]", i), "must be an object")
+			continue
+		}
+		in := fieldValidator{fields: input, prefix: fmt.Sprintf("inputs[%d].", i)}
+		in.known("seq", "client_time", "action", "direction", "word")
+		if seq, ok := in.requiredUint("seq"); ok {
+			if seq == 0 {
+				in.fail("seq", "must be at least 1")
+			} else if i > 0 && seq <= lastSeq {
+				in.fail("seq", "must be greater than the seq of the input before it")
+			}
+			lastSeq = seq
+		}
+		in.optionalUint("client_time")
+		in.action()
+		v.problems = append(v.problems, in.problems...)
+	}
+	return v.problems
+}
+
+func validateStartGameRequest(fields map[string]json.RawMessage) []FieldError {
+	v := fieldValidator{fields: fields}
+	v.known("player", "type")
+	if player, ok := v.optionalString("player"); ok {
+		if _, valid := domain.NormalizePlayerName(player); !valid {
+			v.fail("player", "must be at most 20 letters, digits, spaces, dashes or underscores")
+		}
+	}
+	if gameType, ok := v.optionalString("type"); ok && !v.failed("type") {
+		if names := games.Names(); !slices.Contains(names, gameType) {
+			v.fail("type", "must be one of "+strings.Join(names, ", "))
+		}
+	}
+	return v.problems
+}
+
+func validateGuessRequest(fields map[string]json.RawMessage) []FieldError {
+	v := fieldValidator{fields: fields}
+	v.known("game_id", "guess")
+	v.requiredString("game_id")
+	// Whether the guess is a word is the game's call, so that it is counted
+	// in wordle_invalid_guesses_total
+	v.requiredString("guess")
+	return v.problems
+}
+
+func validateSeatRequest(fields map[string]json.RawMessage) []FieldError {
+	v := fieldValidator{fields: fields}
+	v.known("game_id")
+	v.requiredString("game_id")
+	return v.problems
+}
+
+// fieldValidator accumulates problems so clients see all of them at once.
+// Prefix locates nested objects, such as "inputs[2].", in reported fields.
+type fieldValidator struct {
+	fields   map[string]json.RawMessage
+	prefix   string
+	problems []FieldError
+}
+
+func (v *fieldValidator) fail(field, message string) {
+	v.problems = append(v.problems, FieldError{Field: v.prefix + field, Message: message})
+}
+
+// action checks the action of one input against those of the registered
+// game types, and that it comes with the parameter it takes and no other
+func (v *fieldValidator) action() {
+	action, _ := v.requiredString("action")
+	direction, _ := v.optionalString("direction")
+	if direction != "" && direction != "left" && direction != "right" {
+		v.fail("direction", "must be one of left, right")
+	}
+	word, _ := v.optionalString("word")
+
+	if action == "" {
+		return
+	}
+	spec, ok := games.LookupAction(action)
+	if !ok {
+		v.fail("action", "must be one of "+strings.Join(games.ActionNames(), ", "))
+		return
+	}
+	v.param(spec, games.ParamDirection, direction)
+	v.param(spec, games.ParamWord, word)
+}
+
+// param checks that the field named param is set exactly when spec takes it
+func (v *fieldValidator) param(spec games.ActionSpec, param, value string) {
+	switch {
+	case v.failed(param):
+	case spec.Param == param && value == "":
+		v.fail(param, fmt.Sprintf("is required when action is %s", spec.Name))
+	case spec.Param != param && value != "":
+		v.fail(param, fmt.Sprintf("is not allowed when action is %s", spec.Name))
+	}
+}
+
+// known reports every field that is not in names, in a stable order
+func (v *fieldValidator) known(names ...string) {
+	allowed := make(map[string]bool, len(names))
+	for _, name := range names {
+		allowed[name] = true
+	}
+	var unknown []string
+	for field := range v.fields {
+		if !allowed[field] {
+			unknown = append(unknown, field)
+		}
+	}
+	sort.Strings(unknown)
+	for _, field := range unknown {
+		v.fail(field, "unknown field, expected one of "+strings.Join(names, ", "))
+	}
+}
+
+func (v *fieldValidator) requiredString(field string) (string, bool) {
+	value, ok := v.optionalString(field)
+	if !ok {
+		v.fail(field, "is required")
+		return "", false
+	}
+	if value == "" && !v.failed(field) {
+		v.fail(field, "must not be empty")
+	}
+	return value, ok
+}
+
+func (v *fieldValidator) optionalString(field string) (string, bool) {
+	raw, ok := v.fields[field]
+	if !ok || string(raw) == "null" {
+		return "", false
+	}
+	var value string
+	if err := json.Unmarshal(raw, &value); err != nil {
+		v.fail(field, "must be a string")
+		return "", true
+	}
+	return value, true
+}
+
+func (v *fieldValidator) requiredUint(field string) (uint64, bool) {
+	value, ok := v.optionalUint(field)
+	if !ok && !v.failed(field) {
+		v.fail(field, "is required")
+	}
+	return value, ok
+}
+
+// optionalUint reads a non-negative integer; ok is false when the field is
+// absent or malformed
+func (v *fieldValidator) optionalUint(field string) (uint64, bool) {
+	raw, ok := v.fields[field]
+	if !ok || string(raw) == "null" {
+		return 0, false
+	}
+	var value uint64
+	if err := json.Unmarshal(raw, &value); err != nil {
+		v.fail(field, "must be a non-negative integer")
+		return 0, false
+	}
+	return value, true
+}
+
+func (v *fieldValidator) failed(field string) bool {
+	for _, problem := range v.problems {
+		if problem.Field == v.prefix+field {
+			return true
+		}
+	}
+	return false
+}
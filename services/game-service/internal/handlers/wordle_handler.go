@@ -0,0 +1,133 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+
+	"portfolio-game-service/internal/domain"
+	"portfolio-game-service/internal/games"
+	"portfolio-game-service/internal/services"
+
+	"github.com/sirupsen/logrus"
+)
+
+// WordleHandler serves the word game's own endpoints. They are shorthands
+// for starting a wordle game and sending it guess actions through the
+// generic game endpoints.
+type WordleHandler struct {
+	gameService *services.GameService
+	logger      *logrus.Logger
+}
+
+// GuessRequest is the body of a Wordle guess
+type GuessRequest struct {
+	GameID string `json:"game_id"`
+	Guess  string `json:"guess"`
+}
+
+func NewWordleHandler(gameService *services.GameService, logger *logrus.Logger) *WordleHandler {
+	return &WordleHandler{
+		gameService: gameService,
+		logger:      logger,
+	}
+}
+
+// ValidateStart checks the optional start body
+func (h *WordleHandler) ValidateStart(next http.HandlerFunc) http.HandlerFunc {
+	return validate(h.logger, validateStartGameRequest, true, next)
+}
+
+// ValidateGuess checks guess bodies so malformed words never reach the game
+func (h *WordleHandler) ValidateGuess(next http.HandlerFunc) http.HandlerFunc {
+	return validate(h.logger, validateGuessRequest, false, next)
+}
+
+// StartGame starts or resumes the caller's game for today's word
+func (h *WordleHandler) StartGame(w http.ResponseWriter, r *http.Request) {
+	var req StartGameRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+		h.writeError(w, r, errInvalidBody)
+		return
+	}
+
+	playerID, ok := playerIdentity(r)
+	if !ok {
+		h.writeError(w, r, errInvalidPlayerID)
+		return
+	}
+
+	// The session nickname wins over one sent in the body
+	name := req.Player
+	if header := r.Header.Get(PlayerNameHeader); header != "" {
+		name = header
+	}
+	player, ok := domain.NormalizePlayerName(name)
+	if !ok {
+		h.writeError(w, r, errInvalidPlayerName)
+		return
+	}
+
+	game, err := h.gameService.StartGame(games.TypeWordle, playerID, player)
+	if err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+
+	h.writeJSON(w, game, http.StatusCreated)
+}
+
+// Guess scores one guess and returns the game with its feedback
+func (h *WordleHandler) Guess(w http.ResponseWriter, r *http.Request) {
+	var req GuessRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.writeError(w, r, errInvalidBody)
+		return
+	}
+
+	playerID, ok := playerIdentity(r)
+	if !ok {
+		h.writeError(w, r, errInvalidPlayerID)
+		return
+	}
+
+	game, err := h.gameService.MakeMove(req.GameID, playerID, games.Action{Name: "guess", Word: req.Guess}, 0)
+	if err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+
+	h.writeJSON(w, game, http.StatusOK)
+}
+
+func (h *WordleHandler) GetStatus(w http.ResponseWriter, r *http.Request) {
+	gameID := r.URL.Query().Get("game_id")
+	if gameID == "" {
+		h.writeError(w, r, errMissingGameID)
+		return
+	}
+
+	game, err := h.gameService.GetGameStatus(gameID)
+	if err == nil && game.Info().Type != games.TypeWordle {
+		err = domain.ErrGameNotFound
+	}
+	if err != nil {
+		h.writeError(w, r, err)
+		return
+	}
+
+	h.writeJSON(w, game, http.StatusOK)
+}
+
+func (h *WordleHandler) writeJSON(w http.ResponseWriter, data interface{}, status int) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(data); err != nil {
+		h.logger.WithError(err).Error("Failed to encode JSON response")
+	}
+}
+
+func (h *WordleHandler) writeError(w http.ResponseWriter, r *http.Request, err error) {
+	writeProblem(w, r, h.logger, err)
+}
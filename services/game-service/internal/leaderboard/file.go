@@ -0,0 +1,96 @@
+package leaderboard
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/sirupsen/logrus"
+)
+
+// FileStore serves rankings from memory and appends every entry to a JSON
+// lines file, which is read back on open.
+type FileStore struct {
+	*MemoryStore
+	file *os.File
+}
+
+func NewFileStore(path string, logger *logrus.Logger) (*FileStore, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, fmt.Errorf("create storage directory: %w", err)
+	}
+
+	s := &FileStore{MemoryStore: NewMemoryStore()}
+	if err := s.load(path, logger); err != nil {
+		return nil, err
+	}
+
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("open leaderboard: %w", err)
+	}
+	s.file = file
+
+	logger.WithFields(logrus.Fields{
+		"path":    path,
+		"entries": len(s.entries),
+	}).Info("Leaderboard loaded")
+
+	return s, nil
+}
+
+func (s *FileStore) Add(entry Entry) error {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if _, err := s.file.Write(append(data, '\n')); err != nil {
+		return err
+	}
+	s.insert(entry)
+	return nil
+}
+
+func (s *FileStore) Close() error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return s.file.Close()
+}
+
+func (s *FileStore) load(path string, logger *logrus.Logger) error {
+	file, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("open leaderboard: %w", err)
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	skipped := 0
+	for scanner.Scan() {
+		var entry Entry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			// A crash mid-write leaves a truncated final line
+			skipped++
+			continue
+		}
+		s.insert(entry)
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("read leaderboard: %w", err)
+	}
+
+	if skipped > 0 {
+		logger.WithField("skipped", skipped).Warn("Ignored unreadable leaderboard records")
+	}
+	return nil
+}
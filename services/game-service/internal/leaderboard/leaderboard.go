@@ -0,0 +1,78 @@
+package leaderboard
+
+import (
+	"fmt"
+	"time"
+
+	"portfolio-game-service/internal/domain"
+
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	PeriodAll  = "all"
+	PeriodDay  = "day"
+	PeriodWeek = "week"
+)
+
+var (
+	ErrPlayerNotRanked = domain.NewError("player_not_ranked", "player has no ranked score")
+	ErrInvalidPeriod   = domain.NewError("invalid_period", "invalid leaderboard period")
+)
+
+// Entry is the final result of one finished game
+type Entry struct {
+	GameID     string    `json:"game_id"`
+	PlayerID   string    `json:"player_id,omitempty"`
+	Player     string    `json:"player"`
+	Score      int       `json:"score"`
+	Level      int       `json:"level"`
+	AchievedAt time.Time `json:"achieved_at"`
+}
+
+type RankedEntry struct {
+	Rank int `json:"rank"`
+	Entry
+}
+
+// Store keeps final scores. Rankings are arcade style: every game is its own
+// entry, and a player's rank is where their personal best sits overall.
+type Store interface {
+	Add(entry Entry) error
+	Top(n int, since time.Time) ([]RankedEntry, error)
+	// PersonalBest looks a player up by id; nicknames are not unique
+	PersonalBest(playerID string) (RankedEntry, error)
+	// History returns a player's n most recent entries, newest first
+	History(playerID string, n int) ([]Entry, error)
+	Close() error
+}
+
+// Open returns the store implementation selected by backend
+func Open(backend, path string, logger *logrus.Logger) (Store, error) {
+	switch backend {
+	case "memory":
+		return NewMemoryStore(), nil
+	case "file":
+		return NewFileStore(path, logger)
+	default:
+		return nil, fmt.Errorf("unknown storage backend %q", backend)
+	}
+}
+
+// PeriodStart returns the beginning of the current UTC day or week (weeks
+// start on Monday), or the zero time for all-time rankings.
+func PeriodStart(period string, now time.Time) (time.Time, error) {
+	now = now.UTC()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	switch period {
+	case "", PeriodAll:
+		return time.Time{}, nil
+	case PeriodDay:
+		return today, nil
+	case PeriodWeek:
+		sinceMonday := (int(today.Weekday()) + 6) % 7
+		return today.AddDate(0, 0, -sinceMonday), nil
+	default:
+		return time.Time{}, ErrInvalidPeriod
+	}
+}
@@ -0,0 +1,170 @@
+package leaderboard
+
+import (
+	"errors"
+	"io"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// A Wednesday afternoon; its week started on Monday the 12th
+var now = time.Date(2026, time.October, 14, 15, 0, 0, 0, time.UTC)
+
+func addAll(t *testing.T, s Store, entries ...Entry) {
+	t.Helper()
+	for _, entry := range entries {
+		if err := s.Add(entry); err != nil {
+			t.Fatalf("adding %s: %v", entry.GameID, err)
+		}
+	}
+}
+
+func gameIDs(ranked []RankedEntry) []string {
+	ids := make([]string, len(ranked))
+	for i, entry := range ranked {
+		ids[i] = entry.GameID
+	}
+	return ids
+}
+
+func assertTop(t *testing.T, got []RankedEntry, want ...string) {
+	t.Helper()
+	if len(got) != len(want) {
+		t.Fatalf("top = %v, want %v", gameIDs(got), want)
+	}
+	for i, entry := range got {
+		if entry.GameID != want[i] || entry.Rank != i+1 {
+			t.Fatalf("top = %v, want %v ranked from 1", gameIDs(got), want)
+		}
+	}
+}
+
+func TestTopOrdersBestFirst(t *testing.T) {
+	s := NewMemoryStore()
+	addAll(t, s,
+		Entry{GameID: "low", Player: "ann", Score: 100, Level: 1, AchievedAt: now},
+		Entry{GameID: "high", Player: "bob", Score: 900, Level: 3, AchievedAt: now},
+		Entry{GameID: "tie-later", Player: "cat", Score: 500, Level: 2, AchievedAt: now.Add(time.Minute)},
+		Entry{GameID: "tie-level", Player: "dan", Score: 500, Level: 1, AchievedAt: now.Add(-time.Hour)},
+		Entry{GameID: "tie-first", Player: "eve", Score: 500, Level: 2, AchievedAt: now},
+	)
+
+	top, err := s.Top(4, time.Time{})
+	if err != nil {
+		t.Fatalf("reading top: %v", err)
+	}
+	// Equal scores rank by level, then by who got there first
+	assertTop(t, top, "high", "tie-first", "tie-later", "tie-level")
+}
+
+func TestTopWithinPeriod(t *testing.T) {
+	s := NewMemoryStore()
+	addAll(t, s,
+		Entry{GameID: "last-week", Player: "ann", Score: 900, AchievedAt: time.Date(2026, time.October, 11, 23, 59, 0, 0, time.UTC)},
+		Entry{GameID: "monday", Player: "bob", Score: 700, AchievedAt: time.Date(2026, time.October, 12, 0, 0, 0, 0, time.UTC)},
+		Entry{GameID: "yesterday", Player: "cat", Score: 500, AchievedAt: now.AddDate(0, 0, -1)},
+		Entry{GameID: "today", Player: "dan", Score: 300, AchievedAt: now.Add(-time.Hour)},
+	)
+
+	tests := []struct {
+		period string
+		want   []string
+	}{
+		{PeriodAll, []string{"last-week", "monday", "yesterday", "today"}},
+		{PeriodWeek, []string{"monday", "yesterday", "today"}},
+		{PeriodDay, []string{"today"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.period, func(t *testing.T) {
+			since, err := PeriodStart(tt.period, now)
+			if err != nil {
+				t.Fatalf("period start: %v", err)
+			}
+			top, err := s.Top(10, since)
+			if err != nil {
+				t.Fatalf("reading top: %v", err)
+			}
+			assertTop(t, top, tt.want...)
+		})
+	}
+}
+
+func TestPeriodStart(t *testing.T) {
+	sunday := time.Date(2026, time.October, 18, 23, 0, 0, 0, time.UTC)
+	monday := time.Date(2026, time.October, 12, 0, 0, 0, 0, time.UTC)
+	if start, _ := PeriodStart(PeriodWeek, sunday); !start.Equal(monday) {
+		t.Errorf("week of Sunday starts %v, want %v", start, monday)
+	}
+	if start, _ := PeriodStart(PeriodWeek, monday); !start.Equal(monday) {
+		t.Errorf("week of Monday starts %v, want %v", start, monday)
+	}
+
+	// Days are UTC days wherever the caller is
+	local := time.Date(2026, time.October, 15, 1, 0, 0, 0, time.FixedZone("UTC+3", 3*60*60))
+	if start, _ := PeriodStart(PeriodDay, local); !start.Equal(time.Date(2026, time.October, 14, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("day of %v starts %v, want the 14th UTC", local, start)
+	}
+
+	if _, err := PeriodStart("month", now); !errors.Is(err, ErrInvalidPeriod) {
+		t.Errorf("got %v, want %v", err, ErrInvalidPeriod)
+	}
+}
+
+func TestPersonalBest(t *testing.T) {
+	s := NewMemoryStore()
+	addAll(t, s,
+		Entry{GameID: "a1", PlayerID: "ann-id", Player: "Ann", Score: 300, AchievedAt: now},
+		Entry{GameID: "b1", PlayerID: "bob-id", Player: "bob", Score: 800, AchievedAt: now},
+		Entry{GameID: "a2", PlayerID: "ann-id", Player: "Ann", Score: 600, AchievedAt: now},
+		// Someone else playing under the same nickname
+		Entry{GameID: "x1", PlayerID: "other-id", Player: "ann", Score: 700, AchievedAt: now},
+		Entry{GameID: "anon", Player: "Ann", Score: 900, AchievedAt: now},
+	)
+
+	best, err := s.PersonalBest("ann-id")
+	if err != nil {
+		t.Fatalf("personal best: %v", err)
+	}
+	if best.GameID != "a2" || best.Rank != 4 {
+		t.Errorf("personal best = %s at rank %d, want a2 at rank 4", best.GameID, best.Rank)
+	}
+
+	// Anonymous games have no id and belong to nobody
+	for _, id := range []string{"zed-id", ""} {
+		if _, err := s.PersonalBest(id); !errors.Is(err, ErrPlayerNotRanked) {
+			t.Errorf("PersonalBest(%q) got %v, want %v", id, err, ErrPlayerNotRanked)
+		}
+	}
+}
+
+func TestFileStoreReloads(t *testing.T) {
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+	path := filepath.Join(t.TempDir(), "leaderboard.jsonl")
+
+	s, err := NewFileStore(path, logger)
+	if err != nil {
+		t.Fatalf("opening store: %v", err)
+	}
+	addAll(t, s,
+		Entry{GameID: "second", Player: "ann", Score: 100, AchievedAt: now},
+		Entry{GameID: "first", Player: "bob", Score: 200, AchievedAt: now},
+	)
+	if err := s.Close(); err != nil {
+		t.Fatalf("closing store: %v", err)
+	}
+
+	reopened, err := NewFileStore(path, logger)
+	if err != nil {
+		t.Fatalf("reopening store: %v", err)
+	}
+	defer reopened.Close()
+	top, err := reopened.Top(10, time.Time{})
+	if err != nil {
+		t.Fatalf("reading top: %v", err)
+	}
+	assertTop(t, top, "first", "second")
+}
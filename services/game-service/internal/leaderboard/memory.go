@@ -0,0 +1,102 @@
+package leaderboard
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+type MemoryStore struct {
+	// entries is kept sorted best first
+	entries []Entry
+	mutex   sync.RWMutex
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{}
+}
+
+func (s *MemoryStore) Add(entry Entry) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.insert(entry)
+	return nil
+}
+
+// insert must be called with the mutex held
+func (s *MemoryStore) insert(entry Entry) {
+	i := sort.Search(len(s.entries), func(i int) bool {
+		return ranksBefore(entry, s.entries[i])
+	})
+	s.entries = append(s.entries, Entry{})
+	copy(s.entries[i+1:], s.entries[i:])
+	s.entries[i] = entry
+}
+
+func (s *MemoryStore) Top(n int, since time.Time) ([]RankedEntry, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	top := make([]RankedEntry, 0, n)
+	for _, entry := range s.entries {
+		if len(top) == n {
+			break
+		}
+		if entry.AchievedAt.Before(since) {
+			continue
+		}
+		top = append(top, RankedEntry{Rank: len(top) + 1, Entry: entry})
+	}
+	return top, nil
+}
+
+func (s *MemoryStore) PersonalBest(playerID string) (RankedEntry, error) {
+	if playerID == "" {
+		return RankedEntry{}, ErrPlayerNotRanked
+	}
+
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	for i, entry := range s.entries {
+		if entry.PlayerID == playerID {
+			return RankedEntry{Rank: i + 1, Entry: entry}, nil
+		}
+	}
+	return RankedEntry{}, ErrPlayerNotRanked
+}
+
+func (s *MemoryStore) History(playerID string, n int) ([]Entry, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	var history []Entry
+	for _, entry := range s.entries {
+		if entry.PlayerID == playerID {
+			history = append(history, entry)
+		}
+	}
+	sort.Slice(history, func(i, j int) bool {
+		return history[i].AchievedAt.After(history[j].AchievedAt)
+	})
+	if len(history) > n {
+		history = history[:n]
+	}
+	return history, nil
+}
+
+func (s *MemoryStore) Close() error {
+	return nil
+}
+
+// ranksBefore orders by score, then level, then whoever got there first
+func ranksBefore(a, b Entry) bool {
+	if a.Score != b.Score {
+		return a.Score > b.Score
+	}
+	if a.Level != b.Level {
+		return a.Level > b.Level
+	}
+	return a.AchievedAt.Before(b.AchievedAt)
+}
@@ -0,0 +1,145 @@
+package levels
+
+import (
+	"bytes"
+	"embed"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"sort"
+	"strings"
+
+	"portfolio-game-service/internal/domain"
+
+	"gopkg.in/yaml.v3"
+)
+
+//go:embed defaults/*.yaml
+var defaultFiles embed.FS
+
+// Errors collects every problem found while loading level files so designers
+// can fix them in one pass.
+type Errors []error
+
+func (e Errors) Error() string {
+	messages := make([]string, len(e))
+	for i, err := range e {
+		messages[i] = err.Error()
+	}
+	return fmt.Sprintf("%d level definition error(s):\n  %s", len(e), strings.Join(messages, "\n  "))
+}
+
+// Default returns the built-in levels shipped with the service
+func Default() (*domain.LevelSet, error) {
+	sub, err := fs.Sub(defaultFiles, "defaults")
+	if err != nil {
+		return nil, err
+	}
+	return Load(sub)
+}
+
+// bossFile is the stem of the optional file configuring boss levels, such as
+// boss.yaml; every other file holds a level
+const bossFile = "boss"
+
+// LoadDir reads every .json, .yaml and .yml file in dir as one level
+// definition, apart from the boss file
+func LoadDir(dir string) (*domain.LevelSet, error) {
+	return Load(os.DirFS(dir))
+}
+
+func Load(fsys fs.FS) (*domain.LevelSet, error) {
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return nil, fmt.Errorf("read level directory: %w", err)
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Name() < entries[j].Name() })
+
+	var defs []domain.LevelDef
+	var boss *domain.BossDef
+	var errs Errors
+	seen := make(map[int]string)
+	for _, entry := range entries {
+		if entry.IsDir() || !isLevelFile(entry.Name()) {
+			continue
+		}
+
+		name := entry.Name()
+		if isBossFile(name) {
+			if boss != nil {
+				errs = append(errs, fmt.Errorf("%s: only one boss file is allowed", name))
+				continue
+			}
+			boss = &domain.BossDef{}
+			if err := parseFile(fsys, name, boss); err != nil {
+				errs = append(errs, fmt.Errorf("%s: %w", name, err))
+				continue
+			}
+			for _, problem := range boss.Validate() {
+				errs = append(errs, fmt.Errorf("%s: %w", name, problem))
+			}
+			continue
+		}
+
+		var def domain.LevelDef
+		err := parseFile(fsys, name, &def)
+		if err == nil && def.Boss != nil {
+			err = fmt.Errorf("boss: bosses are configured in the %s file, not per level", bossFile)
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", name, err))
+			continue
+		}
+		for _, problem := range def.Validate() {
+			errs = append(errs, fmt.Errorf("%s: %w", name, problem))
+		}
+		if other, exists := seen[def.Number]; exists {
+			errs = append(errs, fmt.Errorf("%s: number: level %d is already defined in %s", name, def.Number, other))
+			continue
+		}
+		seen[def.Number] = name
+		defs = append(defs, def)
+	}
+	if len(errs) > 0 {
+		return nil, errs
+	}
+
+	return domain.NewLevelSet(defs, boss)
+}
+
+func isLevelFile(name string) bool {
+	switch strings.ToLower(path.Ext(name)) {
+	case ".json", ".yaml", ".yml":
+		return true
+	}
+	return false
+}
+
+func isBossFile(name string) bool {
+	return strings.EqualFold(strings.TrimSuffix(name, path.Ext(name)), bossFile)
+}
+
+// parseFile decodes the file into v, a level or boss definition
+func parseFile(fsys fs.FS, name string, v interface{}) error {
+	data, err := fs.ReadFile(fsys, name)
+	if err != nil {
+		return err
+	}
+
+	// Unknown fields are rejected so typos don't silently fall back to zero values
+	if strings.ToLower(path.Ext(name)) == ".json" {
+		decoder := json.NewDecoder(bytes.NewReader(data))
+		decoder.DisallowUnknownFields()
+		err = decoder.Decode(v)
+	} else {
+		decoder := yaml.NewDecoder(bytes.NewReader(data))
+		decoder.KnownFields(true)
+		err = decoder.Decode(v)
+	}
+	if err != nil {
+		return fmt.Errorf("parse: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,170 @@
+package levels
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+const validLevel = `number: %d
+world: {width: 800, height: 600}
+pattern: sweep
+enemy_speed: 2
+fire_interval: 40
+bullet_speed: 8
+bonus: 50
+grid: {count: 10, columns: 5, origin: {x: 100, y: 50}, spacing_x: 80, spacing_y: 50}
+`
+
+func level(number int) *fstest.MapFile {
+	return &fstest.MapFile{Data: []byte(fmt.Sprintf(validLevel, number))}
+}
+
+func TestDefaultLevelsLoad(t *testing.T) {
+	set, err := Default()
+	if err != nil {
+		t.Fatalf("loading default levels: %v", err)
+	}
+	if set.Len() != 6 {
+		t.Errorf("default levels = %d, want 6", set.Len())
+	}
+	if set.Level(5).Boss == nil {
+		t.Error("level 5 has no boss")
+	}
+}
+
+func TestLoadSkipsOtherFiles(t *testing.T) {
+	set, err := Load(fstest.MapFS{
+		"01.yaml":     level(1),
+		"README.md":   {Data: []byte("# notes")},
+		"old/02.yaml": {Data: []byte("not: [a level")},
+	})
+	if err != nil {
+		t.Fatalf("loading levels: %v", err)
+	}
+	if set.Len() != 1 {
+		t.Errorf("levels = %d, want 1", set.Len())
+	}
+}
+
+func TestLoadReportsEveryProblem(t *testing.T) {
+	tests := []struct {
+		name  string
+		files fstest.MapFS
+		want  []string
+	}{
+		{
+			name: "invalid values",
+			files: fstest.MapFS{
+				"01.yaml": {Data: []byte(`number: 1
+world: {width: 100, height: 600}
+pattern: spiral
+enemy_speed: -1
+fire_interval: 0
+bullet_speed: 8
+bonus: 50
+power_ups: {drop_chance: 150}
+positions: [{x: 10, y: 10}]
+`)},
+			},
+			want: []string{
+				"01.yaml: world.width: must be at least 200, got 100",
+				`01.yaml: pattern: unknown movement pattern "spiral"`,
+				"01.yaml: enemy_speed: must not be negative, got -1",
+				"01.yaml: fire_interval: must be at least 1 tick, got 0",
+				"01.yaml: power_ups.drop_chance: must be a percentage from 0 to 100, got 150",
+			},
+		},
+		{
+			name: "layout",
+			files: fstest.MapFS{
+				"01.yaml": level(1),
+				"02.json": {Data: []byte(`{"number": 2, "world": {"width": 800, "height": 600}, "pattern": "sweep",
+					"fire_interval": 40, "bullet_speed": 8}`)},
+				"03.yaml": {Data: []byte(fmt.Sprintf(validLevel, 3) + "positions: [{x: 10, y: 10}]\n")},
+			},
+			want: []string{
+				"02.json: positions: a level needs either positions or a grid",
+				"03.yaml: grid: cannot be combined with positions",
+			},
+		},
+		{
+			name: "unknown field",
+			files: fstest.MapFS{
+				"01.yaml": {Data: []byte(fmt.Sprintf(validLevel, 1) + "enemy_sped: 3\n")},
+			},
+			want: []string{"01.yaml: parse: ", "field enemy_sped not found"},
+		},
+		{
+			name: "duplicate number",
+			files: fstest.MapFS{
+				"01.yaml": level(1),
+				"02.yaml": level(1),
+			},
+			want: []string{"02.yaml: number: level 1 is already defined in 01.yaml"},
+		},
+		{
+			name: "boss in a level file",
+			files: fstest.MapFS{
+				"01.json": {Data: []byte(`{"number": 1, "boss": {"every": 1}}`)},
+			},
+			want: []string{"01.json: boss: bosses are configured in the boss file, not per level"},
+		},
+		{
+			name: "invalid boss",
+			files: fstest.MapFS{
+				"01.yaml": level(1),
+				"boss.yaml": {Data: []byte(`every: 0
+hp: 10
+phases:
+  - {health: 90, attack: laser, fire_interval: 10, speed: 2}
+`)},
+			},
+			want: []string{
+				"boss.yaml: every: must be at least 1, got 0",
+				"boss.yaml: phases[0].health: the first phase must start at 100, got 90",
+				`boss.yaml: phases[0].attack: unknown attack "laser", expected single, spread or barrage`,
+			},
+		},
+		{
+			name: "two boss files",
+			files: fstest.MapFS{
+				"01.yaml":   level(1),
+				"boss.yaml": {Data: []byte("every: 5\nhp: 10\nphases: [{health: 100, attack: single, fire_interval: 10}]\n")},
+				"boss.yml":  {Data: []byte("every: 5\nhp: 10\nphases: [{health: 100, attack: single, fire_interval: 10}]\n")},
+			},
+			want: []string{"boss.yml: only one boss file is allowed"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Load(tt.files)
+			var errs Errors
+			if !errors.As(err, &errs) {
+				t.Fatalf("got %v, want level definition errors", err)
+			}
+			for _, want := range tt.want {
+				found := false
+				for _, problem := range errs {
+					found = found || strings.Contains(problem.Error(), want)
+				}
+				if !found {
+					t.Errorf("missing error %q in:\n%v", want, err)
+				}
+			}
+		})
+	}
+}
+
+func TestLoadRejectsGaps(t *testing.T) {
+	_, err := Load(fstest.MapFS{
+		"01.yaml": level(1),
+		"03.yaml": level(3),
+	})
+	if err == nil || !strings.Contains(err.Error(), "without gaps") {
+		t.Errorf("got %v, want a numbering error", err)
+	}
+}
@@ -0,0 +1,272 @@
+package repository
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"portfolio-game-service/internal/domain"
+	"portfolio-game-service/internal/games"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Rewrite the log once it holds this many records and is mostly superseded saves
+const compactMinRecords = 1000
+
+const (
+	opSave   = "save"
+	opDelete = "delete"
+)
+
+// logRecord is one line of the log. Type is empty in records written before
+// there were game types, which are all the default type.
+type logRecord struct {
+	Op   string          `json:"op"`
+	ID   string          `json:"id"`
+	Type string          `json:"type,omitempty"`
+	Game json.RawMessage `json:"game,omitempty"`
+}
+
+// FileRepository keeps games in memory and appends every change to a JSON
+// lines log. The log is replayed on open and compacted as it grows.
+// Compaction rewrites the last record saved for each game rather than
+// encoding the games again, so it never reads a game that is changing.
+type FileRepository struct {
+	path    string
+	games   map[string]games.Game
+	saved   map[string]logRecord
+	file    *os.File
+	writer  *bufio.Writer
+	records int
+	mutex   sync.Mutex
+	logger  *logrus.Logger
+}
+
+func NewFileRepository(path string, logger *logrus.Logger) (*FileRepository, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, fmt.Errorf("create storage directory: %w", err)
+	}
+
+	r := &FileRepository{
+		path:   path,
+		games:  make(map[string]games.Game),
+		saved:  make(map[string]logRecord),
+		logger: logger,
+	}
+	if err := r.load(); err != nil {
+		return nil, err
+	}
+	if err := r.compact(); err != nil {
+		return nil, err
+	}
+
+	logger.WithFields(logrus.Fields{
+		"path":  path,
+		"games": len(r.games),
+	}).Info("Game repository loaded")
+
+	return r, nil
+}
+
+func (r *FileRepository) Get(id string) (games.Game, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	game, exists := r.games[id]
+	if !exists {
+		return nil, domain.ErrGameNotFound
+	}
+	return game, nil
+}
+
+func (r *FileRepository) Save(game games.Game) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	record, err := saveRecord(game)
+	if err != nil {
+		return err
+	}
+	r.games[record.ID] = game
+	return r.append(record)
+}
+
+func (r *FileRepository) Checkpoint(clone games.Game) error {
+	// Encoding a long input log takes a while, so it happens before locking
+	record, err := saveRecord(clone)
+	if err != nil {
+		return err
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if _, exists := r.games[record.ID]; !exists {
+		return nil
+	}
+	return r.append(record)
+}
+
+func (r *FileRepository) Delete(id string) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if _, exists := r.games[id]; !exists {
+		return nil
+	}
+	delete(r.games, id)
+	delete(r.saved, id)
+	return r.append(logRecord{Op: opDelete, ID: id})
+}
+
+func (r *FileRepository) List() ([]games.Game, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	list := make([]games.Game, 0, len(r.games))
+	for _, game := range r.games {
+		list = append(list, game)
+	}
+	return list, nil
+}
+
+func (r *FileRepository) Close() error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if err := r.writer.Flush(); err != nil {
+		r.file.Close()
+		return err
+	}
+	return r.file.Close()
+}
+
+func (r *FileRepository) load() error {
+	file, err := os.Open(r.path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("open game log: %w", err)
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	skipped := 0
+	for scanner.Scan() {
+		var record logRecord
+		if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+			// A crash mid-write leaves a truncated final line
+			skipped++
+			continue
+		}
+		switch record.Op {
+		case opSave:
+			if record.Game == nil {
+				continue
+			}
+			game, err := games.Decode(record.Type, record.Game)
+			if err != nil {
+				skipped++
+				continue
+			}
+			r.games[record.ID] = game
+			r.saved[record.ID] = record
+		case opDelete:
+			delete(r.games, record.ID)
+			delete(r.saved, record.ID)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("read game log: %w", err)
+	}
+
+	if skipped > 0 {
+		r.logger.WithField("skipped", skipped).Warn("Ignored unreadable game log records")
+	}
+	return nil
+}
+
+func (r *FileRepository) append(record logRecord) error {
+	data, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+	data = append(data, '\n')
+	if _, err := r.writer.Write(data); err != nil {
+		return err
+	}
+	if err := r.writer.Flush(); err != nil {
+		return err
+	}
+	if record.Op == opSave {
+		r.saved[record.ID] = record
+	}
+
+	r.records++
+	if r.records >= compactMinRecords && r.records > 4*len(r.games) {
+		return r.compact()
+	}
+	return nil
+}
+
+// compact rewrites the log with the last save record of each live game and
+// swaps it in atomically, so a crash during compaction leaves the previous
+// log intact.
+func (r *FileRepository) compact() error {
+	tmpPath := r.path + ".tmp"
+	tmp, err := os.OpenFile(tmpPath, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0o644)
+	if err != nil {
+		return fmt.Errorf("create compacted log: %w", err)
+	}
+
+	writer := bufio.NewWriter(tmp)
+	encoder := json.NewEncoder(writer)
+	for _, record := range r.saved {
+		if err := encoder.Encode(record); err != nil {
+			tmp.Close()
+			return fmt.Errorf("write compacted log: %w", err)
+		}
+	}
+	if err := writer.Flush(); err != nil {
+		tmp.Close()
+		return fmt.Errorf("write compacted log: %w", err)
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return fmt.Errorf("sync compacted log: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	if err := os.Rename(tmpPath, r.path); err != nil {
+		return fmt.Errorf("replace game log: %w", err)
+	}
+	if r.file != nil {
+		r.file.Close()
+	}
+
+	file, err := os.OpenFile(r.path, os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		return fmt.Errorf("reopen game log: %w", err)
+	}
+	r.file = file
+	r.writer = bufio.NewWriter(file)
+	r.records = len(r.saved)
+	return nil
+}
+
+func saveRecord(game games.Game) (logRecord, error) {
+	data, err := json.Marshal(game)
+	if err != nil {
+		return logRecord{}, err
+	}
+	info := game.Info()
+	return logRecord{Op: opSave, ID: info.ID, Type: info.Type, Game: data}, nil
+}
@@ -0,0 +1,106 @@
+package repository
+
+import (
+	"io"
+	"path/filepath"
+	"testing"
+
+	"portfolio-game-service/internal/domain"
+	"portfolio-game-service/internal/games"
+	"portfolio-game-service/internal/levels"
+
+	"github.com/sirupsen/logrus"
+)
+
+func newTestRepository(t *testing.T, path string) *FileRepository {
+	t.Helper()
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+	r, err := NewFileRepository(path, logger)
+	if err != nil {
+		t.Fatalf("opening repository: %v", err)
+	}
+	return r
+}
+
+func newInvaders(t *testing.T, id string) *games.InvadersGame {
+	t.Helper()
+	levelSet, err := levels.Default()
+	if err != nil {
+		t.Fatalf("loading default levels: %v", err)
+	}
+	invaders, err := games.Lookup(games.TypeInvaders)
+	if err != nil {
+		t.Fatalf("looking up type: %v", err)
+	}
+	game, err := invaders.New(id, games.Player{Name: "tester"}, games.Env{Levels: levelSet})
+	if err != nil {
+		t.Fatalf("creating game: %v", err)
+	}
+	return game.(*games.InvadersGame)
+}
+
+func TestCheckpointKeepsHeldGame(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "games.jsonl")
+	r := newTestRepository(t, path)
+
+	live := newInvaders(t, "a")
+	if err := r.Save(live); err != nil {
+		t.Fatalf("saving game: %v", err)
+	}
+	live.Tick()
+	if err := r.Checkpoint(live.Clone()); err != nil {
+		t.Fatalf("checkpointing game: %v", err)
+	}
+	live.Tick()
+
+	held, err := r.Get("a")
+	if err != nil {
+		t.Fatalf("getting game: %v", err)
+	}
+	if held != live {
+		t.Fatal("checkpoint replaced the held game")
+	}
+
+	if err := r.Close(); err != nil {
+		t.Fatalf("closing repository: %v", err)
+	}
+	reopened := newTestRepository(t, path)
+	defer reopened.Close()
+	restored, err := reopened.Get("a")
+	if err != nil {
+		t.Fatalf("getting restored game: %v", err)
+	}
+	if tick := restored.(*games.InvadersGame).Game.Tick; tick != 1 {
+		t.Errorf("restored tick = %d, want the checkpointed 1", tick)
+	}
+}
+
+func TestCheckpointAfterDelete(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "games.jsonl")
+	r := newTestRepository(t, path)
+
+	live := newInvaders(t, "a")
+	if err := r.Save(live); err != nil {
+		t.Fatalf("saving game: %v", err)
+	}
+	clone := live.Clone()
+	if err := r.Delete("a"); err != nil {
+		t.Fatalf("deleting game: %v", err)
+	}
+	if err := r.Checkpoint(clone); err != nil {
+		t.Fatalf("checkpointing game: %v", err)
+	}
+	if _, err := r.Get("a"); err != domain.ErrGameNotFound {
+		t.Errorf("get after checkpoint: got %v, want %v", err, domain.ErrGameNotFound)
+	}
+
+	if err := r.Close(); err != nil {
+		t.Fatalf("closing repository: %v", err)
+	}
+	reopened := newTestRepository(t, path)
+	defer reopened.Close()
+	if _, err := reopened.Get("a"); err != domain.ErrGameNotFound {
+		t.Errorf("get after reopening: got %v, want %v", err, domain.ErrGameNotFound)
+	}
+}
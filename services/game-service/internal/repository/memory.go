@@ -0,0 +1,66 @@
+package repository
+
+import (
+	"sync"
+
+	"portfolio-game-service/internal/domain"
+	"portfolio-game-service/internal/games"
+)
+
+type MemoryRepository struct {
+	games map[string]games.Game
+	mutex sync.RWMutex
+}
+
+func NewMemoryRepository() *MemoryRepository {
+	return &MemoryRepository{
+		games: make(map[string]games.Game),
+	}
+}
+
+func (r *MemoryRepository) Get(id string) (games.Game, error) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	game, exists := r.games[id]
+	if !exists {
+		return nil, domain.ErrGameNotFound
+	}
+	return game, nil
+}
+
+func (r *MemoryRepository) Save(game games.Game) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.games[game.Info().ID] = game
+	return nil
+}
+
+// Checkpoint has nothing to do; the held game is always current
+func (r *MemoryRepository) Checkpoint(clone games.Game) error {
+	return nil
+}
+
+func (r *MemoryRepository) Delete(id string) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	delete(r.games, id)
+	return nil
+}
+
+func (r *MemoryRepository) List() ([]games.Game, error) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	list := make([]games.Game, 0, len(r.games))
+	for _, game := range r.games {
+		list = append(list, game)
+	}
+	return list, nil
+}
+
+func (r *MemoryRepository) Close() error {
+	return nil
+}
@@ -0,0 +1,42 @@
+package repository
+
+import (
+	"fmt"
+
+	"portfolio-game-service/internal/games"
+
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	BackendMemory = "memory"
+	BackendFile   = "file"
+)
+
+// GameRepository stores games of every type between ticks. Implementations
+// hand out the stored game so callers mutate it in place and call Save to
+// persist.
+type GameRepository interface {
+	Get(id string) (games.Game, error)
+	Save(game games.Game) error
+	// Checkpoint persists clone, a copy of a game the repository holds, and
+	// leaves the held game in place. The clone belongs to the repository, so
+	// it can be written while the original keeps changing. A game deleted
+	// since the clone was taken stays deleted.
+	Checkpoint(clone games.Game) error
+	Delete(id string) error
+	List() ([]games.Game, error)
+	Close() error
+}
+
+// Open returns the repository implementation selected by backend
+func Open(backend, path string, logger *logrus.Logger) (GameRepository, error) {
+	switch backend {
+	case BackendMemory:
+		return NewMemoryRepository(), nil
+	case BackendFile:
+		return NewFileRepository(path, logger)
+	default:
+		return nil, fmt.Errorf("unknown storage backend %q", backend)
+	}
+}
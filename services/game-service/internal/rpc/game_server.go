@@ -0,0 +1,254 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+
+	"portfolio-game-service/internal/domain"
+	"portfolio-game-service/internal/games"
+	"portfolio-game-service/internal/services"
+	"portfolio-game-service/pkg/gamepb"
+
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// GameServer exposes services.GameService over gRPC. It shares the game
+// loop, repository and validation with the REST handlers. The protocol
+// describes the arcade game, so it only starts and serves that type.
+type GameServer struct {
+	gamepb.UnimplementedGameServiceServer
+	gameService *services.GameService
+	logger      *logrus.Logger
+}
+
+func NewGameServer(gameService *services.GameService, logger *logrus.Logger) *GameServer {
+	return &GameServer{
+		gameService: gameService,
+		logger:      logger,
+	}
+}
+
+func (s *GameServer) StartGame(ctx context.Context, req *gamepb.StartGameRequest) (*gamepb.StartGameResponse, error) {
+	if !domain.ValidPlayerID(req.GetPlayerId()) {
+		return nil, status.Error(codes.InvalidArgument, "invalid player id")
+	}
+	player, ok := domain.NormalizePlayerName(req.GetPlayerName())
+	if !ok {
+		return nil, status.Error(codes.InvalidArgument, "invalid player name")
+	}
+
+	game, err := s.gameService.StartGame(games.TypeInvaders, req.GetPlayerId(), player)
+	if err != nil {
+		return nil, s.statusError(err)
+	}
+
+	info := game.Info()
+	return &gamepb.StartGameResponse{
+		GameId: info.ID,
+		Status: gameStatus(info.Status),
+	}, nil
+}
+
+func (s *GameServer) MakeMove(ctx context.Context, req *gamepb.MakeMoveRequest) (*gamepb.GameState, error) {
+	if !domain.ValidPlayerID(req.GetPlayerId()) {
+		return nil, status.Error(codes.InvalidArgument, "invalid player id")
+	}
+
+	var action, direction string
+	switch req.GetAction() {
+	case gamepb.Action_ACTION_MOVE:
+		action = "move"
+	case gamepb.Action_ACTION_SHOOT:
+		action = "shoot"
+	default:
+		return nil, status.Error(codes.InvalidArgument, "action is required")
+	}
+	switch req.GetDirection() {
+	case gamepb.Direction_DIRECTION_LEFT:
+		direction = "left"
+	case gamepb.Direction_DIRECTION_RIGHT:
+		direction = "right"
+	}
+
+	game, err := s.gameService.MakeMove(req.GetGameId(), req.GetPlayerId(), games.Action{Name: action, Direction: direction}, 0)
+	if err != nil {
+		return nil, s.statusError(err)
+	}
+	return s.arcadeState(game)
+}
+
+func (s *GameServer) GetStatus(ctx context.Context, req *gamepb.GetStatusRequest) (*gamepb.GameState, error) {
+	game, err := s.gameService.GetGameStatus(req.GetGameId())
+	if err != nil {
+		return nil, s.statusError(err)
+	}
+	return s.arcadeState(game)
+}
+
+// arcadeState converts game, which must be an arcade game, to its message
+func (s *GameServer) arcadeState(game games.Game) (*gamepb.GameState, error) {
+	arcade, ok := game.(*games.InvadersGame)
+	if !ok {
+		return nil, s.statusError(games.ErrNotSupported)
+	}
+	return gameState(arcade.Game), nil
+}
+
+// Subscribe sends the state after every tick and ends the stream once the
+// game is over. A game evicted mid-stream ends it with NotFound.
+func (s *GameServer) Subscribe(req *gamepb.SubscribeRequest, stream gamepb.GameService_SubscribeServer) error {
+	frames, unsubscribe, err := s.gameService.Subscribe(req.GetGameId())
+	if err != nil {
+		return s.statusError(err)
+	}
+	defer unsubscribe()
+
+	ctx := stream.Context()
+	for {
+		select {
+		case <-ctx.Done():
+			return status.FromContextError(ctx.Err()).Err()
+		case game, ok := <-frames:
+			if !ok {
+				return status.Error(codes.NotFound, "game expired")
+			}
+			if err := stream.Send(gameState(game)); err != nil {
+				return err
+			}
+			if game.Status != domain.StatusActive {
+				return nil
+			}
+		}
+	}
+}
+
+func (s *GameServer) statusError(err error) error {
+	switch {
+	case errors.Is(err, domain.ErrGameNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, domain.ErrGameOver):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, domain.ErrInvalidMove):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, domain.ErrInputBacklog):
+		return status.Error(codes.ResourceExhausted, err.Error())
+	case errors.Is(err, domain.ErrGameFull):
+		return status.Error(codes.ResourceExhausted, err.Error())
+	case errors.Is(err, domain.ErrNotSeated):
+		return status.Error(codes.PermissionDenied, err.Error())
+	case errors.Is(err, domain.ErrOutOfOrder):
+		return status.Error(codes.Aborted, err.Error())
+	case errors.Is(err, games.ErrNotSupported):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, games.ErrPlayerRequired):
+		return status.Error(codes.InvalidArgument, err.Error())
+	default:
+		s.logger.WithError(err).Error("gRPC request failed")
+		return status.Error(codes.Internal, "internal error")
+	}
+}
+
+func gameStatus(s domain.GameStatus) gamepb.GameStatus {
+	switch s {
+	case domain.StatusActive:
+		return gamepb.GameStatus_GAME_STATUS_ACTIVE
+	case domain.StatusWon:
+		return gamepb.GameStatus_GAME_STATUS_WON
+	case domain.StatusLost:
+		return gamepb.GameStatus_GAME_STATUS_LOST
+	default:
+		return gamepb.GameStatus_GAME_STATUS_UNSPECIFIED
+	}
+}
+
+func gameState(g *domain.Game) *gamepb.GameState {
+	state := &gamepb.GameState{
+		Id:           g.ID,
+		Status:       gameStatus(g.Status),
+		Tick:         g.Tick,
+		Score:        int32(g.Score),
+		Level:        int32(g.Level),
+		Lives:        int32(g.Lives),
+		World:        &gamepb.World{Width: int32(g.World.Width), Height: int32(g.World.Height)},
+		Enemies:      gameObjects(g.Enemies),
+		EnemyBullets: gameObjects(g.EnemyBullets),
+		Flags:        g.Flags,
+		CreatedAt:    timestamppb.New(g.CreatedAt),
+	}
+	for _, ship := range g.Ships {
+		converted := &gamepb.Ship{
+			Slot:              int32(ship.Slot),
+			Object:            gameObject(ship.GameObject),
+			Bullets:           gameObjects(ship.Bullets),
+			Score:             int32(ship.Score),
+			FireCooldown:      int32(ship.FireCooldown),
+			InvulnerableTicks: int32(ship.InvulnerableTicks),
+		}
+		for _, effect := range ship.Effects {
+			converted.Effects = append(converted.Effects, &gamepb.Effect{Kind: effect.Kind, ExpiresAt: effect.ExpiresAt})
+		}
+		state.Ships = append(state.Ships, converted)
+	}
+	for _, seat := range g.Seats {
+		state.Seats = append(state.Seats, &gamepb.Seat{
+			Slot: int32(seat.Slot),
+			Name: seat.Name,
+			Left: seat.Left,
+		})
+	}
+	for _, powerUp := range g.PowerUps {
+		state.PowerUps = append(state.PowerUps, &gamepb.PowerUp{
+			Object: gameObject(powerUp.GameObject),
+			Kind:   powerUp.Kind,
+		})
+	}
+	for _, bunker := range g.Bunkers {
+		state.Bunkers = append(state.Bunkers, bunkerState(bunker))
+	}
+	if g.Boss != nil {
+		state.Boss = &gamepb.Boss{
+			Object:    gameObject(g.Boss.GameObject),
+			Hp:        int32(g.Boss.HP),
+			MaxHp:     int32(g.Boss.MaxHP),
+			Phase:     int32(g.Boss.Phase),
+			Direction: int32(g.Boss.Direction),
+		}
+	}
+	return state
+}
+
+func bunkerState(bunker domain.Bunker) *gamepb.Bunker {
+	converted := &gamepb.Bunker{
+		Id:       bunker.ID,
+		Position: &gamepb.Position{X: int32(bunker.Position.X), Y: int32(bunker.Position.Y)},
+		CellSize: int32(bunker.CellSize),
+		Rows:     make([]*gamepb.BunkerRow, len(bunker.Cells)),
+	}
+	for i, row := range bunker.Cells {
+		cells := make([]int32, len(row))
+		for j, cell := range row {
+			cells[j] = int32(cell)
+		}
+		converted.Rows[i] = &gamepb.BunkerRow{Cells: cells}
+	}
+	return converted
+}
+
+func gameObjects(objects []domain.GameObject) []*gamepb.GameObject {
+	converted := make([]*gamepb.GameObject, len(objects))
+	for i, object := range objects {
+		converted[i] = gameObject(object)
+	}
+	return converted
+}
+
+func gameObject(object domain.GameObject) *gamepb.GameObject {
+	return &gamepb.GameObject{
+		Id:       object.ID,
+		Position: &gamepb.Position{X: int32(object.Position.X), Y: int32(object.Position.Y)},
+		Active:   object.Active,
+	}
+}
@@ -0,0 +1,141 @@
+package rpc
+
+import (
+	"context"
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	"portfolio-game-service/internal/domain"
+	"portfolio-game-service/internal/games"
+	"portfolio-game-service/internal/leaderboard"
+	"portfolio-game-service/internal/levels"
+	"portfolio-game-service/internal/repository"
+	"portfolio-game-service/internal/services"
+	"portfolio-game-service/pkg/gamepb"
+
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+const (
+	testPlayer = "11111111-1111-4111-8111-111111111111"
+	testToken  = "test-service-token"
+)
+
+// newTestClient serves a GameServer that requires testToken over an
+// in-memory connection
+func newTestClient(t *testing.T) gamepb.GameServiceClient {
+	t.Helper()
+	levelSet, err := levels.Default()
+	if err != nil {
+		t.Fatalf("loading default levels: %v", err)
+	}
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+
+	gameService, err := services.NewGameService(repository.NewMemoryRepository(), leaderboard.NewMemoryStore(), games.Env{Levels: levelSet}, logger, time.Hour, time.Second, services.Retention{
+		IdleTTL:       time.Hour,
+		FinishedGrace: time.Hour,
+		Interval:      time.Hour,
+	})
+	if err != nil {
+		t.Fatalf("creating service: %v", err)
+	}
+
+	unary, stream := ServiceTokenInterceptors(testToken)
+	server := grpc.NewServer(grpc.UnaryInterceptor(unary), grpc.StreamInterceptor(stream))
+	gamepb.RegisterGameServiceServer(server, NewGameServer(gameService, logger))
+	listener := bufconn.Listen(1 << 20)
+	go server.Serve(listener)
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.DialContext(context.Background(), "bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatalf("dialling server: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return gamepb.NewGameServiceClient(conn)
+}
+
+func withToken(token string) context.Context {
+	return metadata.AppendToOutgoingContext(context.Background(), ServiceTokenKey, token)
+}
+
+func TestServiceTokenRequired(t *testing.T) {
+	client := newTestClient(t)
+	req := &gamepb.StartGameRequest{PlayerId: testPlayer, PlayerName: "tester"}
+
+	for name, ctx := range map[string]context.Context{
+		"missing": context.Background(),
+		"wrong":   withToken("guessed"),
+	} {
+		if _, err := client.StartGame(ctx, req); status.Code(err) != codes.Unauthenticated {
+			t.Errorf("%s token: got %v, want %v", name, err, codes.Unauthenticated)
+		}
+	}
+	if _, err := client.StartGame(withToken(testToken), req); err != nil {
+		t.Errorf("valid token: %v", err)
+	}
+}
+
+func TestGetStatusSendsBunkers(t *testing.T) {
+	client := newTestClient(t)
+	ctx := withToken(testToken)
+	started, err := client.StartGame(ctx, &gamepb.StartGameRequest{PlayerId: testPlayer, PlayerName: "tester"})
+	if err != nil {
+		t.Fatalf("starting game: %v", err)
+	}
+
+	state, err := client.GetStatus(ctx, &gamepb.GetStatusRequest{GameId: started.GetGameId()})
+	if err != nil {
+		t.Fatalf("getting status: %v", err)
+	}
+	// The first level has four bunkers of 4 rows by 8 cells
+	if len(state.GetBunkers()) != 4 {
+		t.Fatalf("got %d bunkers, want 4", len(state.GetBunkers()))
+	}
+	for _, bunker := range state.GetBunkers() {
+		rows := bunker.GetRows()
+		if len(rows) != 4 || len(rows[0].GetCells()) != 8 || rows[0].GetCells()[0] == 0 {
+			t.Errorf("bunker %s arrived as %v, want 4 intact rows of 8", bunker.GetId(), rows)
+		}
+	}
+	if state.GetBoss() != nil {
+		t.Error("first level has a boss")
+	}
+}
+
+func TestGameStateCarriesPowerUpsAndBoss(t *testing.T) {
+	g := &domain.Game{
+		Ships: []domain.Ship{{
+			Slot:    0,
+			Effects: []domain.Effect{{Kind: domain.PowerUpShield, ExpiresAt: 120}},
+		}},
+		PowerUps: []domain.PowerUp{{GameObject: domain.GameObject{ID: "p1", Active: true}, Kind: domain.PowerUpSpread}},
+		Boss:     &domain.Boss{GameObject: domain.GameObject{ID: "boss"}, HP: 30, MaxHP: 50, Phase: 1, Direction: -1},
+	}
+
+	state := gameState(g)
+	if effects := state.GetShips()[0].GetEffects(); len(effects) != 1 || effects[0].GetKind() != domain.PowerUpShield || effects[0].GetExpiresAt() != 120 {
+		t.Errorf("ship effects = %v, want a shield until tick 120", effects)
+	}
+	if powerUps := state.GetPowerUps(); len(powerUps) != 1 || powerUps[0].GetKind() != domain.PowerUpSpread || powerUps[0].GetObject().GetId() != "p1" {
+		t.Errorf("power-ups = %v, want the falling spread", powerUps)
+	}
+	boss := state.GetBoss()
+	if boss.GetHp() != 30 || boss.GetMaxHp() != 50 || boss.GetPhase() != 1 || boss.GetDirection() != -1 {
+		t.Errorf("boss = %v, want 30 of 50 hp in phase 1 heading left", boss)
+	}
+}
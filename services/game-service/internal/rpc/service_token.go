@@ -0,0 +1,43 @@
+package rpc
+
+import (
+	"context"
+	"crypto/subtle"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// ServiceTokenKey is the metadata key carrying the secret shared with
+// trusted callers, the gRPC counterpart of the X-Service-Token header
+const ServiceTokenKey = "x-service-token"
+
+// ServiceTokenInterceptors reject calls that do not carry token. Requests
+// name their player_id themselves, so only trusted callers may make them.
+func ServiceTokenInterceptors(token string) (grpc.UnaryServerInterceptor, grpc.StreamServerInterceptor) {
+	check := func(ctx context.Context) error {
+		md, _ := metadata.FromIncomingContext(ctx)
+		for _, presented := range md.Get(ServiceTokenKey) {
+			if subtle.ConstantTimeCompare([]byte(token), []byte(presented)) == 1 {
+				return nil
+			}
+		}
+		return status.Error(codes.Unauthenticated, "missing or invalid service token")
+	}
+
+	unary := func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if err := check(ctx); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+	stream := func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if err := check(ss.Context()); err != nil {
+			return err
+		}
+		return handler(srv, ss)
+	}
+	return unary, stream
+}
@@ -0,0 +1,28 @@
+package services
+
+import "portfolio-game-service/internal/domain"
+
+// historyFrames is how many recent snapshots are kept per game so polling
+// clients can be sent a delta. At the default 10 ticks a second that is 3.2
+// seconds, longer than the frontend waits between requests.
+const historyFrames = 32
+
+// frameHistory is a ring of a game's most recent snapshots, indexed by tick.
+// Ticks advance one at a time, so a slot holds the newest tick with that
+// remainder; stored snapshots are never modified.
+type frameHistory struct {
+	frames [historyFrames]*domain.Game
+}
+
+func (h *frameHistory) add(snapshot *domain.Game) {
+	h.frames[snapshot.Tick%historyFrames] = snapshot
+}
+
+// at returns the snapshot taken at tick, or nil once it has been overwritten
+func (h *frameHistory) at(tick uint64) *domain.Game {
+	snapshot := h.frames[tick%historyFrames]
+	if snapshot == nil || snapshot.Tick != tick {
+		return nil
+	}
+	return snapshot
+}
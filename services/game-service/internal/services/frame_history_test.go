@@ -0,0 +1,47 @@
+package services
+
+import (
+	"testing"
+
+	"portfolio-game-service/internal/domain"
+	"portfolio-game-service/internal/games"
+)
+
+func TestFrameHistoryWrapsAround(t *testing.T) {
+	var h frameHistory
+	const last = historyFrames + 5
+	for tick := uint64(0); tick <= last; tick++ {
+		h.add(&domain.Game{Tick: tick})
+	}
+
+	for tick := uint64(0); tick <= last; tick++ {
+		held := h.at(tick) != nil
+		if want := tick > last-historyFrames; held != want {
+			t.Errorf("tick %d held = %v, want %v", tick, held, want)
+		}
+	}
+	if h.at(last+1) != nil {
+		t.Error("holds a tick that has not happened yet")
+	}
+}
+
+func TestFrameSinceEvictedBase(t *testing.T) {
+	s := newTestService(t)
+	game, err := s.StartGame(games.TypeInvaders, testPlayer, "tester")
+	if err != nil {
+		t.Fatalf("starting game: %v", err)
+	}
+	gameID := game.Info().ID
+	for i := 0; i < historyFrames+5; i++ {
+		s.tick()
+	}
+	current := liveInvaders(t, s, gameID).Game.Snapshot()
+
+	if frame := s.FrameSince(current, current.Tick-1); frame.Type != domain.FrameDelta || frame.Delta.BaseSeq != current.Tick-1 {
+		t.Errorf("frame since the previous tick is a %s, want a delta from it", frame.Type)
+	}
+	// The client's base has left the ring, so it starts over from a keyframe
+	if frame := s.FrameSince(current, 1); frame.Type != domain.FrameState || frame.Game != current {
+		t.Errorf("frame since an evicted tick is a %s, want a keyframe", frame.Type)
+	}
+}
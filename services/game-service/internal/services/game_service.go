@@ -1,105 +1,1022 @@
 package services
 
 import (
+	"context"
 	"crypto/rand"
 	"encoding/hex"
+	"errors"
+	"fmt"
 	"sync"
+	"time"
 
 	"portfolio-game-service/internal/domain"
+	"portfolio-game-service/internal/games"
+	"portfolio-game-service/internal/leaderboard"
+	"portfolio-game-service/internal/repository"
 	"portfolio-game-service/pkg/metrics"
 
 	"github.com/sirupsen/logrus"
 )
 
+// Above keyboard auto-repeat plus rapid firing; faster games are flagged
+const maxInputsPerSecond = 60
+
+// Finished games waiting for their replay to be verified. Games that end
+// while the queue is full are left off the leaderboard.
+const finishQueueSize = 64
+
+// Uploaded replays verified at once. Each re-runs a whole game on the
+// request's goroutine, so uploads beyond this are turned away.
+const maxReplayUploads = 2
+
+var ErrReplaysBusy = domain.NewError("replays_busy", "too many uploaded replays are being verified")
+
+// inputRate counts inputs received for one ship in the current one-second window
+type inputRate struct {
+	windowStart time.Time
+	count       int
+}
+
+// Retention controls when the janitor removes games from the repository
+type Retention struct {
+	IdleTTL       time.Duration
+	FinishedGrace time.Duration
+	Interval      time.Duration
+}
+
+// GameService hosts games of every registered type. Realtime games advance
+// on the game loop; the rest change only when a player acts. Frames, replay
+// verification, anti-cheat flags and the leaderboard are open to any type
+// through the optional interfaces in games; co-op seats and replay downloads
+// belong to the arcade game.
 type GameService struct {
-	games  map[string]*domain.Game
-	mutex  sync.RWMutex
-	logger *logrus.Logger
+	repo        repository.GameRepository
+	scores      leaderboard.Store
+	env         games.Env
+	rates       map[string]map[int]*inputRate
+	subscribers map[string]map[chan *domain.Game]struct{}
+	history     map[string]*frameHistory
+	finished    chan games.Game
+	uploads     chan struct{}
+	// Realtime games are written out by the game loop: when they are in
+	// dirty, and otherwise every persistInterval since persisted
+	dirty           map[string]struct{}
+	persisted       map[string]time.Time
+	mutex           sync.RWMutex
+	logger          *logrus.Logger
+	tickInterval    time.Duration
+	persistInterval time.Duration
+	retention       Retention
 }
 
-func NewGameService(logger *logrus.Logger) *GameService {
+func NewGameService(repo repository.GameRepository, scores leaderboard.Store, env games.Env, logger *logrus.Logger, tickInterval, persistInterval time.Duration, retention Retention) (*GameService, error) {
+	// Games restored from storage may need the environment, such as the
+	// level set, to progress
+	stored, err := repo.List()
+	if err != nil {
+		return nil, err
+	}
+	for _, game := range stored {
+		if restorer, ok := game.(games.Restorer); ok {
+			restorer.Restore(env)
+		}
+	}
+
 	return &GameService{
-		games:  make(map[string]*domain.Game),
-		logger: logger,
+		repo:            repo,
+		scores:          scores,
+		env:             env,
+		rates:           make(map[string]map[int]*inputRate),
+		subscribers:     make(map[string]map[chan *domain.Game]struct{}),
+		history:         make(map[string]*frameHistory),
+		finished:        make(chan games.Game, finishQueueSize),
+		uploads:         make(chan struct{}, maxReplayUploads),
+		dirty:           make(map[string]struct{}),
+		persisted:       make(map[string]time.Time),
+		logger:          logger,
+		tickInterval:    tickInterval,
+		persistInterval: persistInterval,
+		retention:       retention,
+	}, nil
+}
+
+// Run advances every active realtime game at the configured tick rate and
+// evicts expired games until ctx is cancelled. Finished games are verified on
+// a worker of their own, so a long replay never holds up a tick. Running games
+// are written out and queued games verified on the way out.
+func (s *GameService) Run(ctx context.Context) {
+	verified := make(chan struct{})
+	go func() {
+		for game := range s.finished {
+			s.finishGame(game)
+		}
+		close(verified)
+	}()
+	defer func() {
+		close(s.finished)
+		<-verified
+	}()
+
+	ticker := time.NewTicker(s.tickInterval)
+	defer ticker.Stop()
+	janitor := time.NewTicker(s.retention.Interval)
+	defer janitor.Stop()
+
+	s.logger.WithField("tick_interval", s.tickInterval.String()).Info("Game loop started")
+	for {
+		select {
+		case <-ctx.Done():
+			s.flush()
+			s.logger.Info("Game loop stopped")
+			return
+		case <-ticker.C:
+			s.tick()
+		case now := <-janitor.C:
+			s.evictExpired(now)
+		}
+	}
+}
+
+func (s *GameService) tick() {
+	var finished []games.Game
+	var checkpoints []games.Game
+	s.mutex.Lock()
+	defer func() {
+		s.mutex.Unlock()
+		// Writes can take a while, so they run after the lock is released.
+		// Finished games are queued once written, so a flag added by their
+		// replay is never overwritten by the final checkpoint.
+		s.checkpoint(checkpoints)
+		for _, game := range finished {
+			s.queueFinished(game)
+		}
+	}()
+
+	now := time.Now()
+	stored, err := s.repo.List()
+	if err != nil {
+		s.logger.WithError(err).Error("Failed to list games")
+		return
+	}
+
+	active := make(map[string]int)
+	for _, game := range stored {
+		info := game.Info()
+		if info.Status != domain.StatusActive {
+			continue
+		}
+		if t, err := games.Lookup(info.Type); err != nil || !t.Realtime {
+			active[info.Type]++
+			continue
+		}
+
+		game.Tick()
+		info = game.Info()
+		if info.Status == domain.StatusActive {
+			active[info.Type]++
+		} else {
+			delete(s.rates, info.ID)
+			if cloner, ok := game.(games.Cloner); ok {
+				finished = append(finished, cloner.Clone())
+			}
+		}
+		if info.Status != domain.StatusActive || s.checkpointDue(info.ID, now) {
+			if clone := s.copyForCheckpoint(game, now); clone != nil {
+				checkpoints = append(checkpoints, clone)
+			}
+		}
+		if streamer, ok := game.(games.Streamer); ok {
+			s.publish(info.ID, streamer.Frame())
+		}
+		s.recordEnd(info)
+	}
+	setActiveGames(active)
+}
+
+// queueFinished hands a finished game to the verification worker without
+// waiting for it
+func (s *GameService) queueFinished(game games.Game) {
+	select {
+	case s.finished <- game:
+	default:
+		s.logger.WithField("game_id", game.Info().ID).Error("Replay queue full, game left unverified")
+	}
+}
+
+// flush writes out every active realtime game
+func (s *GameService) flush() {
+	var checkpoints []games.Game
+	s.mutex.Lock()
+	stored, err := s.repo.List()
+	if err != nil {
+		s.mutex.Unlock()
+		s.logger.WithError(err).Error("Failed to list games")
+		return
+	}
+	now := time.Now()
+	for _, game := range stored {
+		info := game.Info()
+		if t, err := games.Lookup(info.Type); err != nil || !t.Realtime || info.Status != domain.StatusActive {
+			continue
+		}
+		if clone := s.copyForCheckpoint(game, now); clone != nil {
+			checkpoints = append(checkpoints, clone)
+		}
+	}
+	s.mutex.Unlock()
+
+	s.checkpoint(checkpoints)
+}
+
+// checkpointDue reports whether the game loop should write out a realtime
+// game, because something besides the simulation changed it or the persist
+// interval has passed. It must be called with the mutex held.
+func (s *GameService) checkpointDue(gameID string, now time.Time) bool {
+	if _, dirty := s.dirty[gameID]; dirty {
+		return true
+	}
+	last, seen := s.persisted[gameID]
+	if !seen {
+		// Just started or restored, so what is stored is current
+		s.persisted[gameID] = now
+		return false
+	}
+	return now.Sub(last) >= s.persistInterval
+}
+
+// copyForCheckpoint returns a copy of game to write out once the mutex is
+// released. Games that cannot be copied are saved on the spot and nil is
+// returned. It must be called with the mutex held.
+func (s *GameService) copyForCheckpoint(game games.Game, now time.Time) games.Game {
+	info := game.Info()
+	delete(s.dirty, info.ID)
+	if info.Status == domain.StatusActive {
+		s.persisted[info.ID] = now
+	} else {
+		delete(s.persisted, info.ID)
+	}
+
+	if cloner, ok := game.(games.Cloner); ok {
+		return cloner.Clone()
+	}
+	if err := s.repo.Save(game); err != nil {
+		s.logger.WithError(err).WithField("game_id", info.ID).Error("Failed to save game")
+	}
+	return nil
+}
+
+// checkpoint writes out copies taken by copyForCheckpoint. It must be called
+// without the mutex, in the order the copies were taken.
+func (s *GameService) checkpoint(copies []games.Game) {
+	for _, game := range copies {
+		if err := s.repo.Checkpoint(game); err != nil {
+			s.logger.WithError(err).WithField("game_id", game.Info().ID).Error("Failed to save game")
+		}
+	}
+}
+
+// persist saves a game after a change made outside the simulation. Active
+// realtime games are left to the game loop, so the write stays in order with
+// its checkpoints. It must be called with the mutex held.
+func (s *GameService) persist(game games.Game) error {
+	info := game.Info()
+	if t, err := games.Lookup(info.Type); err == nil && t.Realtime && info.Status == domain.StatusActive {
+		s.dirty[info.ID] = struct{}{}
+		return nil
+	}
+	return s.repo.Save(game)
+}
+
+// evictExpired removes active games nobody has touched within the idle TTL and
+// finished games once their grace period has passed. Daily games are kept
+// until their day is over, unless they are left idle.
+func (s *GameService) evictExpired(now time.Time) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	stored, err := s.repo.List()
+	if err != nil {
+		s.logger.WithError(err).Error("Failed to list games")
+		return
+	}
+
+	active := make(map[string]int)
+	for _, game := range stored {
+		info := game.Info()
+		reason := s.expired(info, now)
+		if reason == "" {
+			if info.Status == domain.StatusActive {
+				active[info.Type]++
+			}
+			continue
+		}
+
+		if err := s.repo.Delete(info.ID); err != nil {
+			s.logger.WithError(err).WithField("game_id", info.ID).Error("Failed to evict game")
+			continue
+		}
+		delete(s.rates, info.ID)
+		delete(s.history, info.ID)
+		delete(s.dirty, info.ID)
+		delete(s.persisted, info.ID)
+		s.closeSubscribers(info.ID)
+
+		metrics.GamesEvicted.WithLabelValues(reason).Inc()
+		s.logger.WithFields(logrus.Fields{
+			"game_id": info.ID,
+			"type":    info.Type,
+			"reason":  reason,
+		}).Info("Game evicted")
 	}
+	setActiveGames(active)
 }
 
-func (s *GameService) StartGame() (*domain.Game, error) {
-	gameID := s.generateGameID()
-	
-	game := domain.NewGame(gameID)
-	
+// expired returns why the janitor should evict a game at now, or "" to keep it
+func (s *GameService) expired(info games.Info, now time.Time) string {
+	if t, err := games.Lookup(info.Type); err == nil && t.Daily {
+		switch {
+		case info.Status == domain.StatusActive && now.Sub(info.LastInputAt) >= s.retention.IdleTTL:
+			return "idle"
+		case sameDay(info.CreatedAt, now):
+			return ""
+		case info.Status == domain.StatusActive:
+			return "idle"
+		default:
+			return "finished"
+		}
+	}
+
+	if info.Status == domain.StatusActive {
+		if now.Sub(info.LastInputAt) < s.retention.IdleTTL {
+			return ""
+		}
+		return "idle"
+	}
+	endedAt := info.LastInputAt
+	if info.EndedAt != nil {
+		endedAt = *info.EndedAt
+	}
+	if now.Sub(endedAt) < s.retention.FinishedGrace {
+		return ""
+	}
+	return "finished"
+}
+
+// StartGame creates a game of the named type for the player. playerID is
+// empty for anonymous players, who cannot resume the game, see it in their
+// history or start daily types. For daily types a player who already has the
+// day's game gets it back instead of a new one.
+func (s *GameService) StartGame(typeName, playerID, playerName string) (games.Game, error) {
+	t, err := games.Lookup(typeName)
+	if err != nil {
+		return nil, err
+	}
+
+	if t.Daily && playerID == "" {
+		return nil, games.ErrPlayerRequired
+	}
+
 	s.mutex.Lock()
-	s.games[gameID] = game
+	if t.Daily {
+		if game := s.todaysGame(t.Name, playerID, time.Now()); game != nil {
+			s.mutex.Unlock()
+			return view(game, playerID), nil
+		}
+	}
+
+	game, err := t.New(s.generateGameID(), games.Player{ID: playerID, Name: playerName}, s.env)
+	if err == nil {
+		err = s.repo.Save(game)
+	}
+	if err != nil {
+		s.mutex.Unlock()
+		return nil, err
+	}
+	if streamer, ok := game.(games.Streamer); ok {
+		s.remember(streamer.Frame())
+	}
+	snapshot := view(game, playerID)
 	s.mutex.Unlock()
-	
-	metrics.GamesStarted.Inc()
+
+	gameID := game.Info().ID
+	metrics.GamesStarted.WithLabelValues(t.Name).Inc()
 	s.logger.WithFields(logrus.Fields{
-		"game_id": gameID,
-		"score": game.Score,
+		"game_id":   gameID,
+		"type":      t.Name,
+		"player_id": playerID,
+		"player":    playerName,
 	}).Info("New game started")
-	
-	return game, nil
+
+	return snapshot, nil
+}
+
+// todaysGame finds the player's game of a daily type started on now's UTC
+// day. It must be called with the mutex held.
+func (s *GameService) todaysGame(typeName, playerID string, now time.Time) games.Game {
+	stored, err := s.repo.List()
+	if err != nil {
+		return nil
+	}
+	for _, game := range stored {
+		info := game.Info()
+		if info.Type == typeName && info.PlayerID == playerID && sameDay(info.CreatedAt, now) {
+			return game
+		}
+	}
+	return nil
+}
+
+// ActiveGame returns the most recently started game of the named type the
+// player is still seated in, so a reloaded client can pick it up again.
+func (s *GameService) ActiveGame(playerID, typeName string) (games.Game, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	stored, err := s.repo.List()
+	if err != nil {
+		return nil, err
+	}
+
+	var latest games.Game
+	var latestAt time.Time
+	for _, game := range stored {
+		info := game.Info()
+		if info.Type != typeName || info.Status != domain.StatusActive {
+			continue
+		}
+		if _, err := game.SeatOf(playerID); err != nil {
+			continue
+		}
+		if latest == nil || info.CreatedAt.After(latestAt) {
+			latest, latestAt = game, info.CreatedAt
+		}
+	}
+	if latest == nil {
+		return nil, domain.ErrGameNotFound
+	}
+	return view(latest, playerID), nil
 }
 
-func (s *GameService) MakeMove(gameID, action, direction string) (*domain.Game, error) {
+// PlayerHistory returns the player's most recent finished games. Only games
+// that made it onto the leaderboard are kept once they leave the repository.
+func (s *GameService) PlayerHistory(playerID string, limit int) ([]leaderboard.Entry, error) {
+	return s.scores.History(playerID, limit)
+}
+
+// JoinGame seats the player in a running game. Their ship appears on the next
+// tick; joining a game the player is already seated in is a no-op.
+func (s *GameService) JoinGame(gameID, playerID, playerName string) (games.Game, int, error) {
 	s.mutex.Lock()
 	defer s.mutex.Unlock()
-	
-	game, exists := s.games[gameID]
-	if !exists {
-		return nil, domain.ErrGameNotFound
+
+	arcade, err := s.arcadeGame(gameID)
+	if err != nil {
+		return nil, 0, err
 	}
-	
-	var err error
-	switch action {
-	case "move":
-		err = game.MovePlayer(direction)
-		metrics.GuessesTotal.Inc()
-	case "shoot":
-		err = game.Shoot()
-		metrics.GuessesTotal.Inc()
-	case "update":
-		// Just update game state, no player action
-	default:
-		return nil, domain.ErrInvalidMove
+	game := arcade.Game
+	if game.Status != domain.StatusActive {
+		return nil, 0, domain.ErrGameOver
+	}
+
+	slot, spawn, err := game.TakeSeat(playerID, playerName, time.Now())
+	if err != nil {
+		return nil, 0, err
+	}
+	if spawn {
+		if err := arcade.Join(slot); err != nil {
+			return nil, 0, err
+		}
+		s.logger.WithFields(logrus.Fields{
+			"game_id":   gameID,
+			"player_id": playerID,
+			"player":    playerName,
+			"slot":      slot,
+		}).Info("Player joined game")
+	}
+	if err := s.persist(arcade); err != nil {
+		return nil, 0, err
+	}
+
+	return arcade.Snapshot(), slot, nil
+}
+
+// LeaveGame removes the player's ship on the next tick. The game carries on
+// for everyone else.
+func (s *GameService) LeaveGame(gameID, playerID string) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	arcade, err := s.arcadeGame(gameID)
+	if err != nil {
+		return err
+	}
+	if arcade.Game.Status != domain.StatusActive {
+		return domain.ErrGameOver
+	}
+
+	slot, err := arcade.Game.LeaveSeat(playerID)
+	if err != nil {
+		return err
+	}
+	if err := arcade.Leave(slot); err != nil {
+		return err
+	}
+	if err := s.persist(arcade); err != nil {
+		return err
+	}
+
+	s.logger.WithFields(logrus.Fields{
+		"game_id":   gameID,
+		"player_id": playerID,
+		"slot":      slot,
+	}).Info("Player left game")
+	return nil
+}
+
+// MakeMove hands a player's action to their game. Realtime games queue it for
+// the next tick, so the returned state is from before it takes effect; other
+// games apply it at once.
+//
+// seq is the client's number for the action, for games that track them. A
+// seq already acknowledged for the seat is a retry and is ignored; one that
+// skips ahead fails with ErrOutOfOrder. A seq is only acknowledged once its
+// action is accepted, so a rejected action can be retried with the same seq.
+// Seq 0 is never tracked.
+func (s *GameService) MakeMove(gameID, playerID string, action games.Action, seq uint64) (games.Game, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	game, err := s.repo.Get(gameID)
+	if err != nil {
+		return nil, err
 	}
-	
+	slot, err := game.SeatOf(playerID)
 	if err != nil {
-		metrics.InvalidGuesses.Inc()
 		return nil, err
 	}
-	
-	// Always update game state
-	game.Update()
-	
-	if game.Status == domain.StatusWon {
-		metrics.GamesWon.Inc()
-		s.logger.WithField("game_id", gameID).Info("Game won")
-	} else if game.Status == domain.StatusLost {
-		metrics.GamesLost.Inc()
-		s.logger.WithField("game_id", gameID).Info("Game lost")
-	}
-	
+
+	seqs, sequenced := game.(games.Sequencer)
+	if sequenced {
+		duplicates, err := seqs.CheckSeqs(slot, []uint64{seq})
+		if err != nil {
+			return nil, err
+		}
+		if duplicates > 0 {
+			s.logger.WithFields(logrus.Fields{
+				"game_id": gameID,
+				"slot":    slot,
+				"seq":     seq,
+			}).Debug("Duplicate move ignored")
+			return view(game, playerID), nil
+		}
+	}
+
+	if err := s.apply(game, slot, []games.Action{action}); err != nil {
+		var actionErr *games.ActionError
+		if errors.As(err, &actionErr) {
+			err = actionErr.Err
+		}
+		return nil, err
+	}
+	if sequenced {
+		seqs.AckSeq(slot, seq)
+	}
+
 	s.logger.WithFields(logrus.Fields{
 		"game_id": gameID,
-		"action": action,
-		"score": game.Score,
-	}).Debug("Move processed")
-	
-	return game, nil
+		"slot":    slot,
+		"action":  action.Name,
+	}).Debug("Move applied")
+
+	return view(game, playerID), nil
+}
+
+// Move is one action of a batch. Seq is the client's number for the action
+// and ClientTime its clock in Unix milliseconds when the action happened.
+type Move struct {
+	Seq        uint64
+	ClientTime int64
+	Action     games.Action
 }
 
-func (s *GameService) GetGameStatus(gameID string) (*domain.Game, error) {
+// MakeMoves hands a batch of actions to the player's game, to be applied in
+// order. The batch is all or nothing: when any action is invalid or the game
+// has no room for all of them, none are applied and no seq is acknowledged.
+// Seqs are handled as in MakeMove; leading actions already acknowledged are
+// retries and are dropped.
+func (s *GameService) MakeMoves(gameID, playerID string, moves []Move) (games.Game, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	game, err := s.repo.Get(gameID)
+	if err != nil {
+		return nil, err
+	}
+	slot, err := game.SeatOf(playerID)
+	if err != nil {
+		return nil, err
+	}
+
+	seqs, sequenced := game.(games.Sequencer)
+	if sequenced {
+		numbers := make([]uint64, len(moves))
+		for i, move := range moves {
+			numbers[i] = move.Seq
+		}
+		duplicates, err := seqs.CheckSeqs(slot, numbers)
+		if err != nil {
+			return nil, err
+		}
+		if duplicates > 0 {
+			s.logger.WithFields(logrus.Fields{
+				"game_id":    gameID,
+				"slot":       slot,
+				"duplicates": duplicates,
+			}).Debug("Duplicate moves ignored")
+			moves = moves[duplicates:]
+		}
+	}
+	if len(moves) == 0 {
+		return view(game, playerID), nil
+	}
+
+	actions := make([]games.Action, len(moves))
+	for i, move := range moves {
+		actions[i] = move.Action
+	}
+	if err := s.apply(game, slot, actions); err != nil {
+		var actionErr *games.ActionError
+		if errors.As(err, &actionErr) {
+			err = fmt.Errorf("input %d (seq %d): %w", actionErr.Index, moves[actionErr.Index].Seq, actionErr.Err)
+		}
+		return nil, err
+	}
+	if sequenced {
+		for _, move := range moves {
+			seqs.AckSeq(slot, move.Seq)
+		}
+	}
+
+	s.logger.WithFields(logrus.Fields{
+		"game_id":   gameID,
+		"slot":      slot,
+		"inputs":    len(moves),
+		"first_seq": moves[0].Seq,
+		"last_seq":  moves[len(moves)-1].Seq,
+	}).Debug("Moves applied")
+
+	return view(game, playerID), nil
+}
+
+// apply hands actions to the game. Games the tick loop does not save are
+// saved here, and recorded if the actions ended them. It must be called with
+// the mutex held.
+func (s *GameService) apply(game games.Game, slot int, actions []games.Action) error {
+	if _, ok := game.(games.Flagger); ok {
+		for _, action := range actions {
+			// Polling for state is not an input a player made
+			if action.Name != "update" {
+				s.checkInputRate(game, slot)
+			}
+		}
+	}
+	if err := game.Apply(slot, actions); err != nil {
+		return err
+	}
+
+	info := game.Info()
+	if t, err := games.Lookup(info.Type); err == nil && t.Realtime {
+		return nil
+	}
+	if err := s.repo.Save(game); err != nil {
+		return err
+	}
+	s.recordEnd(info)
+	return nil
+}
+
+// recordEnd counts a game that has just ended
+func (s *GameService) recordEnd(info games.Info) {
+	log := s.logger.WithFields(logrus.Fields{
+		"game_id": info.ID,
+		"type":    info.Type,
+	})
+	switch info.Status {
+	case domain.StatusWon:
+		metrics.GamesWon.WithLabelValues(info.Type).Inc()
+		log.Info("Game won")
+	case domain.StatusLost:
+		metrics.GamesLost.WithLabelValues(info.Type).Inc()
+		log.Info("Game lost")
+	}
+}
+
+func (s *GameService) GetGameStatus(gameID string) (games.Game, error) {
 	s.mutex.RLock()
 	defer s.mutex.RUnlock()
-	
-	game, exists := s.games[gameID]
-	if !exists {
+
+	game, err := s.repo.Get(gameID)
+	if err != nil {
+		return nil, err
+	}
+
+	return game.Snapshot(), nil
+}
+
+// Subscribe returns a channel receiving a frame after every tick of a game
+// that streams them. Slow consumers only ever see the most recent frame. The returned
+// function must be called to release the subscription.
+func (s *GameService) Subscribe(gameID string) (<-chan *domain.Game, func(), error) {
+	return s.subscribe(gameID, false)
+}
+
+// Spectate subscribes to a game that is still in progress. Spectators only
+// receive frames; moves always go through MakeMove and a seat.
+func (s *GameService) Spectate(gameID string) (<-chan *domain.Game, func(), error) {
+	return s.subscribe(gameID, true)
+}
+
+// You returns the player's seat in a game, or nil when they hold none. Frames
+// carry no player ids, so sockets add it to tell a player which ship is theirs.
+func (s *GameService) You(gameID, playerID string) *domain.You {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	arcade, err := s.arcadeGame(gameID)
+	if err != nil {
+		return nil
+	}
+	return arcade.Game.YouOf(playerID)
+}
+
+// FeaturedGame picks the active game with the highest score for spectators,
+// preferring the older game on a tie.
+func (s *GameService) FeaturedGame() (*domain.Game, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	stored, err := s.repo.List()
+	if err != nil {
+		return nil, err
+	}
+
+	var featured *domain.Game
+	for _, candidate := range stored {
+		arcade, ok := candidate.(*games.InvadersGame)
+		if !ok || arcade.Game.Status != domain.StatusActive {
+			continue
+		}
+		game := arcade.Game
+		if featured == nil || game.Score > featured.Score ||
+			(game.Score == featured.Score && game.CreatedAt.Before(featured.CreatedAt)) {
+			featured = game
+		}
+	}
+	if featured == nil {
 		return nil, domain.ErrGameNotFound
 	}
-	
-	return game, nil
+	return featured.Snapshot(), nil
+}
+
+func (s *GameService) subscribe(gameID string, activeOnly bool) (<-chan *domain.Game, func(), error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	game, err := s.repo.Get(gameID)
+	if err != nil {
+		return nil, nil, err
+	}
+	streamer, ok := game.(games.Streamer)
+	if !ok {
+		return nil, nil, fmt.Errorf("%w (%s)", games.ErrNotSupported, game.Info().Type)
+	}
+	if activeOnly && game.Info().Status != domain.StatusActive {
+		return nil, nil, domain.ErrGameOver
+	}
+
+	frames := make(chan *domain.Game, 1)
+	frames <- streamer.Frame()
+	if s.subscribers[gameID] == nil {
+		s.subscribers[gameID] = make(map[chan *domain.Game]struct{})
+	}
+	s.subscribers[gameID][frames] = struct{}{}
+
+	var once sync.Once
+	unsubscribe := func() {
+		once.Do(func() {
+			s.mutex.Lock()
+			defer s.mutex.Unlock()
+			delete(s.subscribers[gameID], frames)
+			if len(s.subscribers[gameID]) == 0 {
+				delete(s.subscribers, gameID)
+			}
+		})
+	}
+
+	return frames, unsubscribe, nil
+}
+
+// publish must be called with the mutex held
+func (s *GameService) publish(gameID string, frame *domain.Game) {
+	s.remember(frame)
+
+	for frames := range s.subscribers[gameID] {
+		// Drop a stale frame the consumer has not picked up yet
+		select {
+		case <-frames:
+		default:
+		}
+		frames <- frame
+	}
+}
+
+// remember keeps snapshot as a base for later deltas. It must be called with
+// the mutex held.
+func (s *GameService) remember(snapshot *domain.Game) {
+	history, exists := s.history[snapshot.ID]
+	if !exists {
+		history = &frameHistory{}
+		s.history[snapshot.ID] = history
+	}
+	history.add(snapshot)
+}
+
+// FrameSince returns current, a snapshot of the game, as a delta from the
+// state at tick since. Clients get a keyframe instead when that state is no
+// longer held or a delta cannot describe the change.
+func (s *GameService) FrameSince(current *domain.Game, since uint64) domain.Frame {
+	s.mutex.RLock()
+	var base *domain.Game
+	if history, exists := s.history[current.ID]; exists {
+		base = history.at(since)
+	}
+	s.mutex.RUnlock()
+
+	if base != nil {
+		if frame, ok := domain.DeltaFrame(base, current); ok {
+			return frame
+		}
+	}
+	return domain.KeyFrame(current)
+}
+
+// closeSubscribers must be called with the mutex held
+func (s *GameService) closeSubscribers(gameID string) {
+	for frames := range s.subscribers[gameID] {
+		close(frames)
+	}
+	delete(s.subscribers, gameID)
+}
+
+// checkInputRate flags games where one ship receives more inputs than a
+// human could send. It must be called with the mutex held.
+func (s *GameService) checkInputRate(game games.Game, slot int) {
+	now := time.Now()
+	gameID := game.Info().ID
+	if s.rates[gameID] == nil {
+		s.rates[gameID] = make(map[int]*inputRate)
+	}
+	rate, exists := s.rates[gameID][slot]
+	if !exists || now.Sub(rate.windowStart) >= time.Second {
+		rate = &inputRate{windowStart: now}
+		s.rates[gameID][slot] = rate
+	}
+	rate.count++
+
+	if rate.count > maxInputsPerSecond {
+		s.flag(game, domain.FlagInputRate, logrus.Fields{
+			"slot":              slot,
+			"inputs_per_second": rate.count,
+		})
+		s.dirty[gameID] = struct{}{}
+	}
+}
+
+// finishGame replays a game that just ended, when its type can be verified,
+// and flags it if the log does not reproduce its outcome. Clean games are
+// recorded on the leaderboard. clone is a copy of the game as it ended.
+func (s *GameService) finishGame(clone games.Game) {
+	verifier, ok := clone.(games.Verifier)
+	if !ok {
+		s.recordScore(clone)
+		return
+	}
+	result := verifier.Verify()
+	if result.ScoreMatches && result.StateMatches != nil && *result.StateMatches {
+		s.recordScore(clone)
+		return
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	gameID := clone.Info().ID
+	game, err := s.repo.Get(gameID)
+	if err != nil {
+		return
+	}
+	s.flag(game, domain.FlagReplayMismatch, logrus.Fields{
+		"reported_score": result.ReportedScore,
+		"replayed_score": result.ReplayedScore,
+	})
+	if err := s.repo.Save(game); err != nil {
+		s.logger.WithError(err).WithField("game_id", gameID).Error("Failed to save game")
+	}
+}
+
+// recordScore adds the leaderboard entries of a game that scores, unless the
+// game was flagged
+func (s *GameService) recordScore(game games.Game) {
+	scorer, ok := game.(games.Scorer)
+	if !ok {
+		return
+	}
+
+	log := s.logger.WithField("game_id", game.Info().ID)
+	if flagger, ok := game.(games.Flagger); ok && len(flagger.Flags()) > 0 {
+		log.WithField("flags", flagger.Flags()).Warn("Flagged game excluded from leaderboard")
+		return
+	}
+
+	for _, entry := range scorer.Entries() {
+		log := log.WithFields(logrus.Fields{
+			"player": entry.Player,
+			"score":  entry.Score,
+		})
+		if err := s.scores.Add(entry); err != nil {
+			log.WithError(err).Error("Failed to record score")
+			continue
+		}
+		log.Info("Score recorded")
+	}
+}
+
+// flag marks a game the anti-cheat checks caught. It must be called with the
+// mutex held.
+func (s *GameService) flag(game games.Game, reason string, fields logrus.Fields) {
+	flagger, ok := game.(games.Flagger)
+	if !ok || !flagger.Flag(reason) {
+		return
+	}
+
+	metrics.GamesFlagged.WithLabelValues(reason).Inc()
+	s.logger.WithFields(fields).WithFields(logrus.Fields{
+		"game_id": game.Info().ID,
+		"reason":  reason,
+	}).Warn("Game flagged by anti-cheat")
+}
+
+// GetReplay returns the seed and input log needed to reproduce the game.
+// Both predict every random draw still to come, so they are only handed out
+// once the game is over.
+func (s *GameService) GetReplay(gameID string) (domain.Replay, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	arcade, err := s.arcadeGame(gameID)
+	if err != nil {
+		return domain.Replay{}, err
+	}
+	if arcade.Game.Status == domain.StatusActive {
+		return domain.Replay{}, domain.ErrGameActive
+	}
+
+	return arcade.Game.Replay(), nil
+}
+
+// VerifyGame replays a stored game's log and compares the outcome with its
+// live state. The replay runs on a copy, outside the lock.
+func (s *GameService) VerifyGame(gameID string) (domain.ReplayResult, error) {
+	s.mutex.RLock()
+	arcade, err := s.arcadeGame(gameID)
+	if err != nil {
+		s.mutex.RUnlock()
+		return domain.ReplayResult{}, err
+	}
+	clone := arcade.Game.Clone()
+	s.mutex.RUnlock()
+
+	result := clone.Verify()
+	s.logger.WithFields(logrus.Fields{
+		"game_id":        gameID,
+		"reported_score": result.ReportedScore,
+		"replayed_score": result.ReplayedScore,
+		"score_matches":  result.ScoreMatches,
+	}).Info("Replay verified")
+
+	return result, nil
+}
+
+// VerifyReplay re-runs an uploaded replay against the service's level set,
+// or returns ErrReplaysBusy when maxReplayUploads are already running
+func (s *GameService) VerifyReplay(replay domain.Replay) (domain.ReplayResult, error) {
+	select {
+	case s.uploads <- struct{}{}:
+		defer func() { <-s.uploads }()
+	default:
+		return domain.ReplayResult{}, ErrReplaysBusy
+	}
+	return domain.VerifyReplay(replay, s.env.Levels), nil
 }
 
 func (s *GameService) generateGameID() string {
@@ -108,3 +1025,37 @@ func (s *GameService) generateGameID() string {
 	return hex.EncodeToString(bytes)
 }
 
+// view snapshots game for playerID, telling them which seat is theirs in
+// games that have seats
+func view(game games.Game, playerID string) games.Game {
+	if viewer, ok := game.(games.Viewer); ok {
+		return viewer.View(playerID)
+	}
+	return game.Snapshot()
+}
+
+// arcadeGame gets an arcade game for the features only it has, such as seats
+// and replays. It must be called with the mutex held.
+func (s *GameService) arcadeGame(gameID string) (*games.InvadersGame, error) {
+	game, err := s.repo.Get(gameID)
+	if err != nil {
+		return nil, err
+	}
+	arcade, ok := game.(*games.InvadersGame)
+	if !ok {
+		return nil, fmt.Errorf("%w (%s)", games.ErrNotSupported, game.Info().Type)
+	}
+	return arcade, nil
+}
+
+// setActiveGames reports the active game count of every registered type,
+// including the ones with none left
+func setActiveGames(counts map[string]int) {
+	for _, name := range games.Names() {
+		metrics.ActiveGames.WithLabelValues(name).Set(float64(counts[name]))
+	}
+}
+
+func sameDay(a, b time.Time) bool {
+	return a.UTC().Format(domain.DayLayout) == b.UTC().Format(domain.DayLayout)
+}
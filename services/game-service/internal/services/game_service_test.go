@@ -0,0 +1,322 @@
+package services
+
+import (
+	"errors"
+	"io"
+	"slices"
+	"testing"
+	"time"
+
+	"portfolio-game-service/internal/domain"
+	"portfolio-game-service/internal/games"
+	"portfolio-game-service/internal/leaderboard"
+	"portfolio-game-service/internal/levels"
+	"portfolio-game-service/internal/repository"
+	"portfolio-game-service/pkg/metrics"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/sirupsen/logrus"
+)
+
+const testPlayer = "11111111-1111-4111-8111-111111111111"
+
+func newTestService(t *testing.T) *GameService {
+	t.Helper()
+	levelSet, err := levels.Default()
+	if err != nil {
+		t.Fatalf("loading default levels: %v", err)
+	}
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+
+	s, err := NewGameService(repository.NewMemoryRepository(), leaderboard.NewMemoryStore(), games.Env{Levels: levelSet}, logger, 100*time.Millisecond, time.Second, Retention{
+		IdleTTL:       time.Hour,
+		FinishedGrace: time.Hour,
+		Interval:      time.Hour,
+	})
+	if err != nil {
+		t.Fatalf("creating service: %v", err)
+	}
+	return s
+}
+
+// liveInvaders returns the game the service is running, not a snapshot
+func liveInvaders(t *testing.T, s *GameService, gameID string) *games.InvadersGame {
+	t.Helper()
+	game, err := s.repo.Get(gameID)
+	if err != nil {
+		t.Fatalf("getting game: %v", err)
+	}
+	return game.(*games.InvadersGame)
+}
+
+// playOut ticks the game until it ends
+func playOut(t *testing.T, s *GameService, gameID string) {
+	t.Helper()
+	for i := 0; i < 100000; i++ {
+		if liveInvaders(t, s, gameID).Game.Status != domain.StatusActive {
+			return
+		}
+		s.tick()
+	}
+	t.Fatal("game did not end")
+}
+
+// verifyQueued does the verification worker's job for games the tick loop queued
+func verifyQueued(s *GameService) {
+	for len(s.finished) > 0 {
+		s.finishGame(<-s.finished)
+	}
+}
+
+func moves(from, to uint64) []Move {
+	var batch []Move
+	for seq := from; seq <= to; seq++ {
+		batch = append(batch, Move{Action: games.Action{Name: "move", Direction: "left"}, Seq: seq})
+	}
+	return batch
+}
+
+func TestMakeMovesRetriesRejectedBatch(t *testing.T) {
+	s := newTestService(t)
+	game, err := s.StartGame(games.TypeInvaders, testPlayer, "tester")
+	if err != nil {
+		t.Fatalf("starting game: %v", err)
+	}
+	gameID := game.Info().ID
+	s.tick()
+
+	// Fill most of the queue so the next batch does not fit
+	if _, err := s.MakeMoves(gameID, testPlayer, moves(1, 30)); err != nil {
+		t.Fatalf("first batch: %v", err)
+	}
+	if _, err := s.MakeMoves(gameID, testPlayer, moves(31, 34)); !errors.Is(err, domain.ErrInputBacklog) {
+		t.Fatalf("second batch: got %v, want %v", err, domain.ErrInputBacklog)
+	}
+	if acked := liveInvaders(t, s, gameID).Game.AckedSeq(testPlayer); acked != 30 {
+		t.Fatalf("acked seq after rejected batch = %d, want 30", acked)
+	}
+
+	s.tick()
+	applied := len(liveInvaders(t, s, gameID).Game.Inputs)
+
+	if _, err := s.MakeMoves(gameID, testPlayer, moves(31, 34)); err != nil {
+		t.Fatalf("retrying batch: %v", err)
+	}
+	s.tick()
+
+	live := liveInvaders(t, s, gameID)
+	if acked := live.Game.AckedSeq(testPlayer); acked != 34 {
+		t.Errorf("acked seq after retry = %d, want 34", acked)
+	}
+	if got := len(live.Game.Inputs) - applied; got != 4 {
+		t.Errorf("retry applied %d inputs, want 4", got)
+	}
+}
+
+func TestMakeMoveRetriesRejectedMove(t *testing.T) {
+	s := newTestService(t)
+	game, err := s.StartGame(games.TypeInvaders, testPlayer, "tester")
+	if err != nil {
+		t.Fatalf("starting game: %v", err)
+	}
+	gameID := game.Info().ID
+	s.tick()
+
+	if _, err := s.MakeMove(gameID, testPlayer, games.Action{Name: "move", Direction: "up"}, 1); err == nil {
+		t.Fatal("move with a bad direction was accepted")
+	}
+	if _, err := s.MakeMove(gameID, testPlayer, games.Action{Name: "move", Direction: "left"}, 1); err != nil {
+		t.Fatalf("retrying seq 1: %v", err)
+	}
+	if acked := liveInvaders(t, s, gameID).Game.AckedSeq(testPlayer); acked != 1 {
+		t.Errorf("acked seq = %d, want 1", acked)
+	}
+}
+
+func TestInputRateIgnoresPolling(t *testing.T) {
+	s := newTestService(t)
+	game, err := s.StartGame(games.TypeInvaders, testPlayer, "tester")
+	if err != nil {
+		t.Fatalf("starting game: %v", err)
+	}
+	gameID := game.Info().ID
+
+	for i := 0; i <= maxInputsPerSecond; i++ {
+		if _, err := s.MakeMove(gameID, testPlayer, games.Action{Name: "update"}, 0); err != nil {
+			t.Fatalf("polling: %v", err)
+		}
+	}
+	if flags := liveInvaders(t, s, gameID).Game.Flags; slices.Contains(flags, domain.FlagInputRate) {
+		t.Fatalf("flags after polling = %v, want no %s", flags, domain.FlagInputRate)
+	}
+
+	for i := 0; i <= maxInputsPerSecond; i++ {
+		if _, err := s.MakeMove(gameID, testPlayer, games.Action{Name: "move", Direction: "left"}, 0); err != nil {
+			t.Fatalf("moving: %v", err)
+		}
+		s.tick()
+	}
+	if flags := liveInvaders(t, s, gameID).Game.Flags; !slices.Contains(flags, domain.FlagInputRate) {
+		t.Errorf("flags after %d moves in a second = %v, want %s", maxInputsPerSecond+1, flags, domain.FlagInputRate)
+	}
+}
+
+func TestUploadedReplaysBounded(t *testing.T) {
+	s := newTestService(t)
+	for i := 0; i < maxReplayUploads; i++ {
+		s.uploads <- struct{}{}
+	}
+	if _, err := s.VerifyReplay(domain.Replay{Seed: 1}); !errors.Is(err, ErrReplaysBusy) {
+		t.Fatalf("verifying with every slot taken: got %v, want %v", err, ErrReplaysBusy)
+	}
+
+	<-s.uploads
+	if _, err := s.VerifyReplay(domain.Replay{Seed: 1}); err != nil {
+		t.Fatalf("verifying with a slot free: %v", err)
+	}
+	if len(s.uploads) != maxReplayUploads-1 {
+		t.Errorf("%d slots taken after verifying, want %d", len(s.uploads), maxReplayUploads-1)
+	}
+}
+
+func TestDailyGameNeedsPlayer(t *testing.T) {
+	s := newTestService(t)
+	if _, err := s.StartGame(games.TypeWordle, "", "anonymous"); !errors.Is(err, games.ErrPlayerRequired) {
+		t.Fatalf("anonymous start: got %v, want %v", err, games.ErrPlayerRequired)
+	}
+
+	game, err := s.StartGame(games.TypeWordle, testPlayer, "tester")
+	if err != nil {
+		t.Fatalf("starting game: %v", err)
+	}
+	if _, err := s.MakeMove(game.Info().ID, "", games.Action{Name: "guess", Word: "crane"}, 0); !errors.Is(err, domain.ErrNotSeated) {
+		t.Errorf("anonymous guess: got %v, want %v", err, domain.ErrNotSeated)
+	}
+}
+
+func TestMalformedGuessCounted(t *testing.T) {
+	s := newTestService(t)
+	game, err := s.StartGame(games.TypeWordle, testPlayer, "tester")
+	if err != nil {
+		t.Fatalf("starting game: %v", err)
+	}
+
+	before := testutil.ToFloat64(metrics.InvalidGuesses)
+	if _, err := s.MakeMove(game.Info().ID, testPlayer, games.Action{Name: "guess", Word: "cr4ne"}, 0); !errors.Is(err, domain.ErrInvalidGuess) {
+		t.Fatalf("malformed guess: got %v, want %v", err, domain.ErrInvalidGuess)
+	}
+	if counted := testutil.ToFloat64(metrics.InvalidGuesses) - before; counted != 1 {
+		t.Errorf("invalid guesses counted %v, want 1", counted)
+	}
+}
+
+func TestDailyGameExpiresWhenIdle(t *testing.T) {
+	s := newTestService(t)
+	game, err := s.StartGame(games.TypeWordle, testPlayer, "tester")
+	if err != nil {
+		t.Fatalf("starting game: %v", err)
+	}
+	info := game.Info()
+
+	if reason := s.expired(info, info.CreatedAt.Add(time.Minute)); reason != "" {
+		t.Errorf("expired after a minute = %q, want kept", reason)
+	}
+	if reason := s.expired(info, info.CreatedAt.Add(s.retention.IdleTTL)); reason != "idle" {
+		t.Errorf("expired after the idle TTL = %q, want idle", reason)
+	}
+}
+
+func TestFinishedGameVerifiedOffTheTick(t *testing.T) {
+	s := newTestService(t)
+	game, err := s.StartGame(games.TypeInvaders, testPlayer, "tester")
+	if err != nil {
+		t.Fatalf("starting game: %v", err)
+	}
+	playOut(t, s, game.Info().ID)
+
+	if queued := len(s.finished); queued != 1 {
+		t.Fatalf("queued games = %d, want 1", queued)
+	}
+	if top, _ := s.scores.Top(10, time.Time{}); len(top) != 0 {
+		t.Fatalf("score recorded before verification: %+v", top)
+	}
+
+	verifyQueued(s)
+	top, err := s.scores.Top(10, time.Time{})
+	if err != nil {
+		t.Fatalf("reading leaderboard: %v", err)
+	}
+	if len(top) != 1 || top[0].GameID != game.Info().ID {
+		t.Errorf("leaderboard = %+v, want the finished game", top)
+	}
+}
+
+func TestFlaggedGameLeftOffLeaderboard(t *testing.T) {
+	tests := []struct {
+		name  string
+		cheat func(s *GameService, live *games.InvadersGame)
+		flag  string
+	}{
+		{
+			name: "flagged while playing",
+			cheat: func(s *GameService, live *games.InvadersGame) {
+				s.flag(live, domain.FlagInputRate, nil)
+			},
+			flag: domain.FlagInputRate,
+		},
+		{
+			name: "score does not replay",
+			cheat: func(s *GameService, live *games.InvadersGame) {
+				live.Game.Score += 1000
+			},
+			flag: domain.FlagReplayMismatch,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newTestService(t)
+			game, err := s.StartGame(games.TypeInvaders, testPlayer, "tester")
+			if err != nil {
+				t.Fatalf("starting game: %v", err)
+			}
+			gameID := game.Info().ID
+			s.tick()
+			tt.cheat(s, liveInvaders(t, s, gameID))
+
+			playOut(t, s, gameID)
+			verifyQueued(s)
+
+			if flags := liveInvaders(t, s, gameID).Flags(); len(flags) != 1 || flags[0] != tt.flag {
+				t.Errorf("flags = %v, want [%s]", flags, tt.flag)
+			}
+			if top, _ := s.scores.Top(10, time.Time{}); len(top) != 0 {
+				t.Errorf("leaderboard = %+v, want the flagged game left off", top)
+			}
+		})
+	}
+}
+
+func TestReplayWithheldUntilGameEnds(t *testing.T) {
+	s := newTestService(t)
+	game, err := s.StartGame(games.TypeInvaders, testPlayer, "tester")
+	if err != nil {
+		t.Fatalf("starting game: %v", err)
+	}
+	gameID := game.Info().ID
+	s.tick()
+
+	if _, err := s.GetReplay(gameID); !errors.Is(err, domain.ErrGameActive) {
+		t.Fatalf("replay of an active game: got %v, want %v", err, domain.ErrGameActive)
+	}
+
+	playOut(t, s, gameID)
+	replay, err := s.GetReplay(gameID)
+	if err != nil {
+		t.Fatalf("replay of a finished game: %v", err)
+	}
+	if replay.GameID != gameID || replay.FinalTick == 0 {
+		t.Errorf("replay = %+v, want the finished game's log", replay)
+	}
+}
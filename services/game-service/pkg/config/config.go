@@ -1,16 +1,44 @@
 package config
 
-import "os"
+import (
+	"os"
+	"strconv"
+	"time"
+)
 
 type Config struct {
-	Port     string
-	LogLevel string
+	Port           string
+	GRPCPort       string
+	LogLevel       string
+	TickRate       int
+	StorageBackend string
+	StoragePath    string
+	ScoresPath     string
+	GameIdleTTL    time.Duration
+	FinishedGrace  time.Duration
+	JanitorPeriod  time.Duration
+	PersistPeriod  time.Duration
+	LevelsDir      string
+	DailyWord      string
+	ServiceToken   string
 }
 
 func Load() *Config {
 	return &Config{
-		Port:     getEnv("PORT", "8080"),
-		LogLevel: getEnv("LOG_LEVEL", "info"),
+		Port:           getEnv("PORT", "8080"),
+		GRPCPort:       getEnv("GRPC_PORT", "9090"),
+		LogLevel:       getEnv("LOG_LEVEL", "info"),
+		TickRate:       getEnvInt("TICK_RATE", 10),
+		StorageBackend: getEnv("STORAGE_BACKEND", "memory"),
+		StoragePath:    getEnv("STORAGE_PATH", "/data/games.jsonl"),
+		ScoresPath:     getEnv("LEADERBOARD_PATH", "/data/leaderboard.jsonl"),
+		GameIdleTTL:    getEnvDuration("GAME_IDLE_TTL", 15*time.Minute),
+		FinishedGrace:  getEnvDuration("FINISHED_GAME_GRACE", 2*time.Minute),
+		JanitorPeriod:  getEnvDuration("JANITOR_INTERVAL", 30*time.Second),
+		PersistPeriod:  getEnvDuration("PERSIST_INTERVAL", 5*time.Second),
+		LevelsDir:      getEnv("LEVELS_DIR", ""),
+		DailyWord:      getEnv("DAILY_WORD", ""),
+		ServiceToken:   getEnv("SERVICE_TOKEN", ""),
 	}
 }
 
@@ -19,4 +47,18 @@ func getEnv(key, defaultValue string) string {
 		return value
 	}
 	return defaultValue
-}
\ No newline at end of file
+}
+
+func getEnvInt(key string, defaultValue int) int {
+	if value, err := strconv.Atoi(os.Getenv(key)); err == nil && value > 0 {
+		return value
+	}
+	return defaultValue
+}
+
+func getEnvDuration(key string, defaultValue time.Duration) time.Duration {
+	if value, err := time.ParseDuration(os.Getenv(key)); err == nil && value > 0 {
+		return value
+	}
+	return defaultValue
+}
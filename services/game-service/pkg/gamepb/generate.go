@@ -0,0 +1,7 @@
+// Package gamepb holds the code generated from proto/game/v1/game.proto.
+// Regenerate it with protoc 25.1, protoc-gen-go v1.31.0 and
+// protoc-gen-go-grpc v1.3.0 on the PATH after editing the proto, so the
+// versions in the generated headers only change on purpose.
+package gamepb
+
+//go:generate protoc -I ../../proto --go_out=. --go_opt=module=portfolio-game-service/pkg/gamepb --go-grpc_out=. --go-grpc_opt=module=portfolio-game-service/pkg/gamepb game/v1/game.proto
@@ -6,36 +6,65 @@ import (
 )
 
 var (
-	// Low-cardinality metrics only - no user IDs or game IDs in labels
-	GamesStarted = promauto.NewCounter(prometheus.CounterOpts{
+	// Low-cardinality metrics only - no user IDs or game IDs in labels.
+	// type is a registered game type name.
+	GamesStarted = promauto.NewCounterVec(prometheus.CounterOpts{
 		Name: "wordle_games_started_total",
 		Help: "Total number of games started",
-	})
+	}, []string{"type"})
 
-	GamesWon = promauto.NewCounter(prometheus.CounterOpts{
+	GamesWon = promauto.NewCounterVec(prometheus.CounterOpts{
 		Name: "wordle_games_won_total",
 		Help: "Total number of games won",
-	})
+	}, []string{"type"})
 
-	GamesLost = promauto.NewCounter(prometheus.CounterOpts{
+	GamesLost = promauto.NewCounterVec(prometheus.CounterOpts{
 		Name: "wordle_games_lost_total",
 		Help: "Total number of games lost",
-	})
+	}, []string{"type"})
 
 	GuessesTotal = promauto.NewCounter(prometheus.CounterOpts{
 		Name: "wordle_guesses_total",
-		Help: "Total number of guesses made",
+		Help: "Total number of Wordle guesses made",
 	})
 
 	InvalidGuesses = promauto.NewCounter(prometheus.CounterOpts{
 		Name: "wordle_invalid_guesses_total",
-		Help: "Total number of invalid guesses",
+		Help: "Total number of rejected Wordle guesses",
 	})
 
-	ActiveGames = promauto.NewGauge(prometheus.GaugeOpts{
+	InputsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "wordle_inputs_total",
+		Help: "Total number of realtime game inputs queued",
+	}, []string{"type"})
+
+	InvalidInputs = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "wordle_invalid_inputs_total",
+		Help: "Total number of rejected realtime game inputs",
+	}, []string{"type"})
+
+	ActiveGames = promauto.NewGaugeVec(prometheus.GaugeOpts{
 		Name: "wordle_active_games",
 		Help: "Number of currently active games",
-	})
+	}, []string{"type"})
+
+	// reason is either "idle" or "finished"
+	GamesEvicted = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "wordle_games_evicted_total",
+		Help: "Total number of games removed by the janitor",
+	}, []string{"reason"})
+
+	// reason is one of the domain.Flag* constants
+	GamesFlagged = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "wordle_games_flagged_total",
+		Help: "Total number of games flagged by anti-cheat checks",
+	}, []string{"reason"})
+
+	// role is either "player" or "spectator"
+	SocketConnections = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "wordle_socket_connections",
+		Help: "Number of open game WebSocket connections",
+	}, []string{"role"})
 )
 
 func Init() {